@@ -0,0 +1,3 @@
+data "ghostwriter_domain" "example" {
+  name = "example.com"
+}
@@ -0,0 +1,10 @@
+data "ghostwriter_domains" "available" {
+  status        = "Available"
+  health_status = "Healthy"
+  expires_after = "2026-01-01"
+  name_pattern  = "%.com"
+}
+
+output "available_domains" {
+  value = data.ghostwriter_domains.available.domains[*].name
+}
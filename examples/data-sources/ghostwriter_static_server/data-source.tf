@@ -0,0 +1,3 @@
+data "ghostwriter_static_server" "teamserver" {
+  name = "teamserver01"
+}
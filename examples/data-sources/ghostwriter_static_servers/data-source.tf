@@ -0,0 +1,8 @@
+data "ghostwriter_static_servers" "available" {
+  server_provider = "Amazon Web Services"
+  server_status   = "Available"
+}
+
+output "available_servers" {
+  value = data.ghostwriter_static_servers.available.servers[*].ip_address
+}
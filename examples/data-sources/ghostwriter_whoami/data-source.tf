@@ -0,0 +1,5 @@
+data "ghostwriter_whoami" "current" {}
+
+output "ghostwriter_user" {
+  value = "${data.ghostwriter_whoami.current.username} (${data.ghostwriter_whoami.current.role})"
+}
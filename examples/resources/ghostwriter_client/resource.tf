@@ -0,0 +1,8 @@
+resource "ghostwriter_client" "example" {
+  name       = "Example Corporation"
+  short_name = "ExCorp"
+  code_name  = "SILENT FALCON"
+  address    = "1 Example Street, London"
+  timezone   = "Europe/London"
+  note       = "Managed by terraform"
+}
@@ -0,0 +1,5 @@
+# Domain allocations are imported by the IDs of their domain checkouts, separated by commas.
+# min_age_days, category and expires_after_project_end only choose the domains when the
+# allocation is created, so they are not imported. Leave them out of the configuration of an
+# imported allocation, as changing them replaces it.
+terraform import ghostwriter_domain_allocation.phishing 12,13
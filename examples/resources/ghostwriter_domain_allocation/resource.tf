@@ -0,0 +1,28 @@
+data "ghostwriter_activity_type" "phishing" {
+  name = "Phishing"
+}
+
+data "ghostwriter_project" "testproject" {
+  code_name = "Test Project"
+}
+
+resource "ghostwriter_domain_allocation" "phishing" {
+  project_id                = data.ghostwriter_project.testproject.id
+  activity_type_id          = data.ghostwriter_activity_type.phishing.id
+  start_date                = data.ghostwriter_project.testproject.start_date
+  end_date                  = data.ghostwriter_project.testproject.end_date
+  domain_count              = 2
+  min_age_days              = 180
+  category                  = "Business"
+  expires_after_project_end = true
+  note                      = "Phishing infrastructure"
+
+  timeouts {
+    create = "10m"
+    delete = "10m"
+  }
+}
+
+output "phishing_domains" {
+  value = ghostwriter_domain_allocation.phishing.domain_names
+}
@@ -0,0 +1,14 @@
+resource "ghostwriter_oplog" "test" {
+  name         = "Test Oplog"
+  project_id   = 1
+  force_delete = true
+}
+
+resource "ghostwriter_oplog_entry" "redirector" {
+  oplog_id      = ghostwriter_oplog.test.id
+  dest_ip       = "203.0.113.10"
+  tool          = "terraform"
+  command       = "terraform apply"
+  description   = "HTTPS redirector stood up"
+  operator_name = "jdoe"
+}
@@ -0,0 +1,12 @@
+resource "ghostwriter_client" "example" {
+  name = "Example Corporation"
+}
+
+resource "ghostwriter_project" "example" {
+  client_id       = ghostwriter_client.example.id
+  project_type_id = 1
+  start_date      = "2024-01-01"
+  end_date        = "2024-02-01"
+  timezone        = "Europe/London"
+  slack_channel   = "#example-engagement"
+}
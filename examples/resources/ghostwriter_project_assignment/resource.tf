@@ -0,0 +1,12 @@
+data "ghostwriter_project" "testproject" {
+  code_name = "Test Project"
+}
+
+resource "ghostwriter_project_assignment" "operator" {
+  project_id = data.ghostwriter_project.testproject.id
+  username   = "jdoe"
+  role       = "Operator"
+  start_date = "2024-01-01"
+  end_date   = "2024-02-01"
+  note       = "Infrastructure and phishing"
+}
@@ -24,7 +24,7 @@ func NewactivitytypeDataSource() datasource.DataSource {
 
 // activitytypeDataSource is the data source implementation.
 type activitytypeDataSource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // activityType maps coffees schema data.
@@ -46,12 +46,12 @@ func (d *activitytypeDataSource) Configure(_ context.Context, req datasource.Con
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,7 +99,9 @@ func (d *activitytypeDataSource) Read(ctx context.Context, req datasource.ReadRe
 	tflog.Debug(ctx, fmt.Sprintf("Querying activity types: %v", plan))
 	request := graphql.NewRequest(queryActivityType)
 	request.Var("name", plan.Name.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		ActivityType []ghostwriterActivityType `json:"activityType"`
+	}
 	if err := d.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter activity types",
@@ -108,13 +110,13 @@ func (d *activitytypeDataSource) Read(ctx context.Context, req datasource.ReadRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
 	// Overwrite items with refreshed state
-	activityTypes := respData["activityType"].([]interface{})
+	activityTypes := respData.ActivityType
 	if len(activityTypes) == 1 {
-		activityType := activityTypes[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(activityType["id"].(float64)))
-		state.Name = types.StringValue(activityType["activity"].(string))
+		activityType := activityTypes[0]
+		state.ID = types.Int64Value(activityType.ID)
+		state.Name = stringValueOrEmpty(activityType.Activity)
 
 		// Set state
 		diags = resp.State.Set(ctx, &state)
@@ -0,0 +1,199 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// audit appends an entry to the audit oplog recording that Terraform created, updated, deleted or
+// otherwise destroyed a resource, along with the state before and after the change. action is
+// one of the keys of auditDescriptions. It does nothing
+// unless audit_oplog_id is configured. Failures are reported as warnings since the change
+// itself has already been made.
+func (c *ghostwriterClient) audit(ctx context.Context, action string, resourceType string, before tftypes.Value, after tftypes.Value) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if c.auditOplogID == 0 {
+		return diags
+	}
+
+	beforeValue := auditValue(before)
+	afterValue := auditValue(after)
+	id := auditResourceID(afterValue)
+	if id == nil {
+		id = auditResourceID(beforeValue)
+	}
+	output, err := json.MarshalIndent(map[string]any{
+		"before": beforeValue,
+		"after":  afterValue,
+	}, "", "  ")
+	if err != nil {
+		diags.AddWarning(
+			"Error Writing Ghostwriter Audit Entry",
+			"Could not encode the change to "+resourceType+" for the audit oplog: "+err.Error(),
+		)
+		return diags
+	}
+
+	const insertauditentry = `mutation InsertAuditEntry ($oplog: bigint, $start_date: timestamptz, $end_date: timestamptz, $tool: String, $user_context: String, $command: String, $description: String, $output: String, $operator_name: String){
+		insert_oplogEntry(objects: {oplog: $oplog, startDate: $start_date, endDate: $end_date, tool: $tool, userContext: $user_context, command: $command, description: $description, output: $output, operatorName: $operator_name}) {
+			returning {
+				id
+			}
+		}
+	}`
+	now := time.Now().UTC().Format(time.RFC3339)
+	request := graphql.NewRequest(insertauditentry)
+	request.Var("oplog", c.auditOplogID)
+	request.Var("start_date", now)
+	request.Var("end_date", now)
+	request.Var("tool", "terraform")
+	request.Var("user_context", c.username)
+	request.Var("command", fmt.Sprintf("terraform %s %s", action, resourceType))
+	request.Var("description", auditDescription(action, resourceType, id))
+	request.Var("output", string(output))
+	request.Var("operator_name", c.username)
+	var respData struct {
+		InsertOplogEntry mutationResponse[ghostwriterOplogEntry] `json:"insert_oplogEntry"`
+	}
+	if err := c.Run(ctx, request, &respData); err != nil {
+		diags.AddWarning(
+			"Error Writing Ghostwriter Audit Entry",
+			fmt.Sprintf("The change to %s ID %v succeeded but could not be recorded in audit oplog ID %d: %s", resourceType, id, c.auditOplogID, err.Error()),
+		)
+		return diags
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Audit entry written: %s", responseString(respData)))
+	return diags
+}
+
+// validateAuditOplog checks the audit oplog exists so that a mistyped audit_oplog_id is
+// reported before any changes are made rather than as a warning after each one.
+func validateAuditOplog(ctx context.Context, client *ghostwriterClient, id int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	const queryoplog = `query QueryOplog ($id: bigint){
+		oplog(where: {id: {_eq: $id}}) {
+			id
+		}
+	}`
+	request := graphql.NewRequest(queryoplog)
+	request.Var("id", id)
+	var respData struct {
+		Oplog []ghostwriterOplog `json:"oplog"`
+	}
+	if err := client.Run(ctx, request, &respData); err != nil {
+		diags.AddAttributeError(
+			path.Root("audit_oplog_id"),
+			"Invalid Ghostwriter Audit Oplog",
+			"Could not read audit oplog ID "+strconv.FormatInt(id, 10)+": "+err.Error(),
+		)
+		return diags
+	}
+	if len(respData.Oplog) == 0 {
+		diags.AddAttributeError(
+			path.Root("audit_oplog_id"),
+			"Invalid Ghostwriter Audit Oplog",
+			"Audit oplog ID "+strconv.FormatInt(id, 10)+" does not exist or is not visible to the configured credentials.",
+		)
+	}
+	return diags
+}
+
+// auditDescriptions maps the audited actions to the wording used in entry descriptions. Resources
+// destroyed without deleting their Ghostwriter record are not reported as deleted, so the oplog
+// only records deletions that happened.
+var auditDescriptions = map[string]string{
+	"create":  "Terraform created %s ID %v",
+	"update":  "Terraform updated %s ID %v",
+	"delete":  "Terraform deleted %s ID %v",
+	"remove":  "Terraform removed %s ID %v from its state and kept the Ghostwriter record",
+	"release": "Terraform released %s ID %v and kept the Ghostwriter record",
+	"expire":  "Terraform expired %s ID %v and kept the Ghostwriter record",
+	"burn":    "Terraform burned %s ID %v and kept the Ghostwriter record",
+}
+
+// auditDescription returns the description of the audit entry for an action.
+func auditDescription(action string, resourceType string, id any) string {
+	description, ok := auditDescriptions[action]
+	if !ok {
+		return fmt.Sprintf("Terraform %s %s ID %v", action, resourceType, id)
+	}
+	return fmt.Sprintf(description, resourceType, id)
+}
+
+// deleteAuditAction returns the audited action of destroying a resource that only deletes its
+// Ghostwriter record when force_delete is true.
+func deleteAuditAction(force_delete types.Bool) string {
+	if force_delete.ValueBool() {
+		return "delete"
+	}
+	return "remove"
+}
+
+// auditResourceID returns the id attribute of a converted resource state, if there is one.
+func auditResourceID(value any) any {
+	attributes, ok := value.(map[string]any)
+	if !ok {
+		return nil
+	}
+	return attributes["id"]
+}
+
+// auditValue converts a raw Terraform value into plain Go values that can be encoded as JSON.
+// Null and unknown values become nil.
+func auditValue(value tftypes.Value) any {
+	if value.Type() == nil || value.IsNull() || !value.IsKnown() {
+		return nil
+	}
+	switch value.Type().(type) {
+	case tftypes.Object, tftypes.Map:
+		var attributes map[string]tftypes.Value
+		if err := value.As(&attributes); err != nil {
+			return nil
+		}
+		converted := make(map[string]any, len(attributes))
+		for name, attribute := range attributes {
+			converted[name] = auditValue(attribute)
+		}
+		return converted
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var elements []tftypes.Value
+		if err := value.As(&elements); err != nil {
+			return nil
+		}
+		converted := make([]any, 0, len(elements))
+		for _, element := range elements {
+			converted = append(converted, auditValue(element))
+		}
+		return converted
+	}
+	switch {
+	case value.Type().Equal(tftypes.String):
+		var s string
+		_ = value.As(&s)
+		return s
+	case value.Type().Equal(tftypes.Bool):
+		var b bool
+		_ = value.As(&b)
+		return b
+	case value.Type().Equal(tftypes.Number):
+		n := new(big.Float)
+		_ = value.As(&n)
+		if i, accuracy := n.Int64(); accuracy == big.Exact {
+			return i
+		}
+		f, _ := n.Float64()
+		return f
+	}
+	return value.String()
+}
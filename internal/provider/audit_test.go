@@ -0,0 +1,295 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/machinebox/graphql"
+)
+
+func TestAuditValue(t *testing.T) {
+	checkout_type := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":         tftypes.Number,
+		"note":       tftypes.String,
+		"force":      tftypes.Bool,
+		"domain_ids": tftypes.List{ElementType: tftypes.Number},
+	}}
+
+	tests := []struct {
+		name  string
+		value tftypes.Value
+		want  any
+	}{
+		{
+			name:  "empty value",
+			value: tftypes.Value{},
+			want:  nil,
+		},
+		{
+			name:  "null",
+			value: tftypes.NewValue(tftypes.String, nil),
+			want:  nil,
+		},
+		{
+			name:  "unknown",
+			value: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			want:  nil,
+		},
+		{
+			name:  "string",
+			value: tftypes.NewValue(tftypes.String, "phishing"),
+			want:  "phishing",
+		},
+		{
+			name:  "bool",
+			value: tftypes.NewValue(tftypes.Bool, true),
+			want:  true,
+		},
+		{
+			name:  "integer",
+			value: tftypes.NewValue(tftypes.Number, big.NewFloat(42)),
+			want:  int64(42),
+		},
+		{
+			name:  "fraction",
+			value: tftypes.NewValue(tftypes.Number, big.NewFloat(1.5)),
+			want:  1.5,
+		},
+		{
+			name: "set",
+			value: tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a"),
+			}),
+			want: []any{"a"},
+		},
+		{
+			name: "map",
+			value: tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"env": tftypes.NewValue(tftypes.String, "prod"),
+			}),
+			want: map[string]any{"env": "prod"},
+		},
+		{
+			name: "object",
+			value: tftypes.NewValue(checkout_type, map[string]tftypes.Value{
+				"id":    tftypes.NewValue(tftypes.Number, big.NewFloat(7)),
+				"note":  tftypes.NewValue(tftypes.String, nil),
+				"force": tftypes.NewValue(tftypes.Bool, false),
+				"domain_ids": tftypes.NewValue(tftypes.List{ElementType: tftypes.Number}, []tftypes.Value{
+					tftypes.NewValue(tftypes.Number, big.NewFloat(1)),
+					tftypes.NewValue(tftypes.Number, big.NewFloat(2)),
+				}),
+			}),
+			want: map[string]any{
+				"id":         int64(7),
+				"note":       nil,
+				"force":      false,
+				"domain_ids": []any{int64(1), int64(2)},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := auditValue(test.value); !reflect.DeepEqual(got, test.want) {
+				t.Errorf("expected %#v, got %#v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAuditResourceID(t *testing.T) {
+	tests := []struct {
+		name  string
+		value any
+		want  any
+	}{
+		{
+			name:  "object with id",
+			value: map[string]any{"id": int64(7), "name": "example.com"},
+			want:  int64(7),
+		},
+		{
+			name:  "object without id",
+			value: map[string]any{"name": "example.com"},
+			want:  nil,
+		},
+		{
+			name:  "destroyed resource",
+			value: nil,
+			want:  nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := auditResourceID(test.value); got != test.want {
+				t.Errorf("expected %v, got %v", test.want, got)
+			}
+		})
+	}
+}
+
+func TestAuditDescription(t *testing.T) {
+	tests := []struct {
+		action string
+		want   string
+	}{
+		{
+			action: "create",
+			want:   "Terraform created ghostwriter_domain_checkout ID 7",
+		},
+		{
+			action: "delete",
+			want:   "Terraform deleted ghostwriter_domain_checkout ID 7",
+		},
+		{
+			action: deleteAuditAction(types.BoolValue(false)),
+			want:   "Terraform removed ghostwriter_domain_checkout ID 7 from its state and kept the Ghostwriter record",
+		},
+		{
+			action: onDestroyAuditActions[onDestroyRelease],
+			want:   "Terraform released ghostwriter_domain_checkout ID 7 and kept the Ghostwriter record",
+		},
+		{
+			action: onDestroyAuditActions[onDestroyExpireNow],
+			want:   "Terraform expired ghostwriter_domain_checkout ID 7 and kept the Ghostwriter record",
+		},
+		{
+			action: onDestroyAuditActions[onDestroyBurn],
+			want:   "Terraform burned ghostwriter_domain_checkout ID 7 and kept the Ghostwriter record",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.action, func(t *testing.T) {
+			if got := auditDescription(test.action, "ghostwriter_domain_checkout", int64(7)); got != test.want {
+				t.Errorf("expected %q, got %q", test.want, got)
+			}
+		})
+	}
+}
+
+func TestDeleteAuditAction(t *testing.T) {
+	if action := deleteAuditAction(types.BoolValue(true)); action != "delete" {
+		t.Errorf("expected delete with force_delete, got %q", action)
+	}
+	if action := deleteAuditAction(types.BoolValue(false)); action != "remove" {
+		t.Errorf("expected remove without force_delete, got %q", action)
+	}
+	if action := deleteAuditAction(types.BoolNull()); action != "remove" {
+		t.Errorf("expected remove with force_delete unset, got %q", action)
+	}
+}
+
+func TestAudit(t *testing.T) {
+	object_type := tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+		"id":   tftypes.Number,
+		"note": tftypes.String,
+	}}
+	before := tftypes.NewValue(object_type, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.Number, big.NewFloat(7)),
+		"note": tftypes.NewValue(tftypes.String, "before"),
+	})
+	after := tftypes.NewValue(object_type, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.Number, big.NewFloat(7)),
+		"note": tftypes.NewValue(tftypes.String, "after"),
+	})
+
+	tests := []struct {
+		name            string
+		auditOplogID    int64
+		action          string
+		before          tftypes.Value
+		after           tftypes.Value
+		wantRequest     bool
+		wantDescription string
+		wantOutput      map[string]any
+	}{
+		{
+			name:         "auditing disabled",
+			auditOplogID: 0,
+			action:       "update",
+			before:       before,
+			after:        after,
+		},
+		{
+			name:            "update",
+			auditOplogID:    3,
+			action:          "update",
+			before:          before,
+			after:           after,
+			wantRequest:     true,
+			wantDescription: "Terraform updated ghostwriter_client ID 7",
+			wantOutput: map[string]any{
+				"before": map[string]any{"id": float64(7), "note": "before"},
+				"after":  map[string]any{"id": float64(7), "note": "after"},
+			},
+		},
+		{
+			name:            "removed from state",
+			auditOplogID:    3,
+			action:          "remove",
+			before:          before,
+			after:           tftypes.Value{},
+			wantRequest:     true,
+			wantDescription: "Terraform removed ghostwriter_client ID 7 from its state and kept the Ghostwriter record",
+			wantOutput: map[string]any{
+				"before": map[string]any{"id": float64(7), "note": "before"},
+				"after":  nil,
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			requested := false
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				requested = true
+				var body struct {
+					Variables struct {
+						Oplog       int64  `json:"oplog"`
+						Command     string `json:"command"`
+						Description string `json:"description"`
+						Output      string `json:"output"`
+					} `json:"variables"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decoding request: %s", err)
+				}
+				if body.Variables.Oplog != test.auditOplogID {
+					t.Errorf("expected oplog %d, got %d", test.auditOplogID, body.Variables.Oplog)
+				}
+				if body.Variables.Description != test.wantDescription {
+					t.Errorf("expected description %q, got %q", test.wantDescription, body.Variables.Description)
+				}
+				var output map[string]any
+				if err := json.Unmarshal([]byte(body.Variables.Output), &output); err != nil {
+					t.Errorf("decoding output: %s", err)
+				}
+				if !reflect.DeepEqual(output, test.wantOutput) {
+					t.Errorf("expected output %#v, got %#v", test.wantOutput, output)
+				}
+				_, _ = w.Write([]byte(`{"data": {"insert_oplogEntry": {"returning": [{"id": 1}]}}}`))
+			}))
+			defer server.Close()
+
+			client := newGhostwriterClient(graphql.NewClient(server.URL))
+			client.auditOplogID = test.auditOplogID
+			diags := client.audit(context.Background(), test.action, "ghostwriter_client", test.before, test.after)
+			if diags.HasError() || diags.WarningsCount() > 0 {
+				t.Fatalf("unexpected diagnostics: %v", diags)
+			}
+			if requested != test.wantRequest {
+				t.Errorf("expected audit entry written %t, got %t", test.wantRequest, requested)
+			}
+		})
+	}
+}
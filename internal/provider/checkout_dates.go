@@ -0,0 +1,114 @@
+package provider
+
+import (
+	"context"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/machinebox/graphql"
+)
+
+// validateDateRange checks the start_date and end_date of a checkout, project or project
+// assignment are real dates and do not end before they start. entity names what the dates
+// belong to in the diagnostics, e.g. "Checkout". Values that are not known yet are checked on
+// the next plan.
+func validateDateRange(start_date types.String, end_date types.String, entity string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if start_date.IsNull() || start_date.IsUnknown() || end_date.IsNull() || end_date.IsUnknown() {
+		return diags
+	}
+
+	start, err := time.Parse(time.DateOnly, start_date.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("start_date"),
+			"Invalid "+entity+" Date",
+			"The "+strings.ToLower(entity)+" start_date "+start_date.String()+" is not a valid date: "+err.Error(),
+		)
+	}
+	end, err := time.Parse(time.DateOnly, end_date.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid "+entity+" Date",
+			"The "+strings.ToLower(entity)+" end_date "+end_date.String()+" is not a valid date: "+err.Error(),
+		)
+	}
+	if diags.HasError() {
+		return diags
+	}
+
+	if end.Before(start) {
+		diags.AddAttributeError(
+			path.Root("end_date"),
+			"Invalid "+entity+" Dates",
+			"The "+strings.ToLower(entity)+" end_date "+end_date.String()+" is before its start_date "+start_date.String()+".",
+		)
+	}
+	return diags
+}
+
+// checkCheckoutProject warns when a checkout ends after the project it belongs to, and reports
+// an error when the project does not exist.
+func (c *ghostwriterClient) checkCheckoutProject(ctx context.Context, project_id types.Int64, end_date types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if project_id.IsNull() || project_id.IsUnknown() || end_date.IsNull() || end_date.IsUnknown() {
+		return diags
+	}
+
+	project, project_diags := c.readProject(ctx, project_id.ValueInt64())
+	diags.Append(project_diags...)
+	if diags.HasError() || project.EndDate == nil {
+		return diags
+	}
+	project_end, err := time.Parse(time.DateOnly, *project.EndDate)
+	if err != nil {
+		return diags
+	}
+	end, err := time.Parse(time.DateOnly, end_date.ValueString())
+	if err == nil && end.After(project_end) {
+		diags.AddAttributeWarning(
+			path.Root("end_date"),
+			"Checkout Extends Beyond Project",
+			"The end_date "+end_date.String()+" is after the end date "+*project.EndDate+" of Ghostwriter project ID "+strconv.FormatInt(project_id.ValueInt64(), 10)+".",
+		)
+	}
+	return diags
+}
+
+// readProject returns the Ghostwriter project with the given ID, reporting an error on project_id
+// when it does not exist.
+func (c *ghostwriterClient) readProject(ctx context.Context, project_id int64) (ghostwriterProject, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	const queryproject = `query QueryProject ($id: bigint) {
+		project(where: {id: {_eq: $id}}) {
+			id
+			endDate
+		}
+	}`
+	request := graphql.NewRequest(queryproject)
+	request.Var("id", project_id)
+	var respData struct {
+		Project []ghostwriterProject `json:"project"`
+	}
+	if err := c.Run(ctx, request, &respData); err != nil {
+		diags.AddError(
+			"Error Reading Ghostwriter Project",
+			"Could not read Ghostwriter project ID "+strconv.FormatInt(project_id, 10)+": "+err.Error(),
+		)
+		return ghostwriterProject{}, diags
+	}
+	if len(respData.Project) != 1 {
+		diags.AddAttributeError(
+			path.Root("project_id"),
+			"Ghostwriter Project Not Found",
+			"Could not find Ghostwriter project ID "+strconv.FormatInt(project_id, 10)+".",
+		)
+		return ghostwriterProject{}, diags
+	}
+	return respData.Project[0], diags
+}
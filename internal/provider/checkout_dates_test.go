@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+func TestValidateDateRange(t *testing.T) {
+	tests := []struct {
+		name        string
+		start       types.String
+		end         types.String
+		entity      string
+		wantSummary string
+		wantDetail  string
+	}{
+		{
+			name:   "valid range",
+			start:  types.StringValue("2024-01-01"),
+			end:    types.StringValue("2024-02-01"),
+			entity: "Checkout",
+		},
+		{
+			name:   "single day",
+			start:  types.StringValue("2024-01-01"),
+			end:    types.StringValue("2024-01-01"),
+			entity: "Project",
+		},
+		{
+			name:   "unknown end date",
+			start:  types.StringValue("2024-02-01"),
+			end:    types.StringUnknown(),
+			entity: "Project",
+		},
+		{
+			name:        "checkout ends before it starts",
+			start:       types.StringValue("2024-02-01"),
+			end:         types.StringValue("2024-01-01"),
+			entity:      "Checkout",
+			wantSummary: "Invalid Checkout Dates",
+			wantDetail:  `The checkout end_date "2024-01-01" is before its start_date "2024-02-01".`,
+		},
+		{
+			name:        "project ends before it starts",
+			start:       types.StringValue("2024-02-01"),
+			end:         types.StringValue("2024-01-01"),
+			entity:      "Project",
+			wantSummary: "Invalid Project Dates",
+			wantDetail:  `The project end_date "2024-01-01" is before its start_date "2024-02-01".`,
+		},
+		{
+			name:        "project assignment ends before it starts",
+			start:       types.StringValue("2024-02-01"),
+			end:         types.StringValue("2024-01-01"),
+			entity:      "Project Assignment",
+			wantSummary: "Invalid Project Assignment Dates",
+			wantDetail:  `The project assignment end_date "2024-01-01" is before its start_date "2024-02-01".`,
+		},
+		{
+			name:        "project assignment date does not exist",
+			start:       types.StringValue("2024-02-30"),
+			end:         types.StringValue("2024-03-01"),
+			entity:      "Project Assignment",
+			wantSummary: "Invalid Project Assignment Date",
+			wantDetail:  `The project assignment start_date "2024-02-30" is not a valid date: parsing time "2024-02-30": day out of range`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			diags := validateDateRange(test.start, test.end, test.entity)
+			if test.wantSummary == "" {
+				if diags.HasError() {
+					t.Fatalf("unexpected errors: %v", diags)
+				}
+				return
+			}
+			if diags.ErrorsCount() != 1 {
+				t.Fatalf("expected 1 error, got %v", diags)
+			}
+			if summary := diags.Errors()[0].Summary(); summary != test.wantSummary {
+				t.Errorf("expected summary %q, got %q", test.wantSummary, summary)
+			}
+			if detail := diags.Errors()[0].Detail(); detail != test.wantDetail {
+				t.Errorf("expected detail %q, got %q", test.wantDetail, detail)
+			}
+		})
+	}
+}
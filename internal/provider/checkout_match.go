@@ -0,0 +1,85 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/machinebox/graphql"
+)
+
+// checkoutMatch finds the checkout created by a checkoutDomain or checkoutServer action on
+// Ghostwriter instances that do not return the new checkout in the action result. Checkouts with
+// the same attributes may already exist, such as released checkouts kept as history, so the
+// highest matching ID is recorded before the action runs and only rows added after it count.
+type checkoutMatch struct {
+	table      string
+	where      map[string]any
+	previousID int64
+}
+
+// newCheckoutMatch returns a checkoutMatch for the checkouts in table whose columns equal values.
+func newCheckoutMatch(table string, values map[string]any) *checkoutMatch {
+	where := map[string]any{}
+	for column, value := range values {
+		where[column] = map[string]any{"_eq": value}
+	}
+	return &checkoutMatch{table: table, where: where}
+}
+
+// recordExisting remembers the checkouts that already match. It must be called before the
+// checkout action runs.
+func (m *checkoutMatch) recordExisting(ctx context.Context, client *ghostwriterClient) error {
+	ids, err := m.matchingIDs(ctx, client, m.where)
+	if err != nil {
+		return err
+	}
+	if len(ids) > 0 {
+		m.previousID = ids[0]
+	}
+	return nil
+}
+
+// createdID returns the ID of the matching checkout added since recordExisting, and an error
+// unless exactly one was added.
+func (m *checkoutMatch) createdID(ctx context.Context, client *ghostwriterClient) (int64, error) {
+	where := map[string]any{
+		"_and": []any{
+			m.where,
+			map[string]any{"id": map[string]any{"_gt": m.previousID}},
+		},
+	}
+	ids, err := m.matchingIDs(ctx, client, where)
+	if err != nil {
+		return 0, err
+	}
+	switch len(ids) {
+	case 0:
+		return 0, fmt.Errorf("no new checkout matches the configuration")
+	case 1:
+		return ids[0], nil
+	default:
+		return 0, fmt.Errorf("%d new checkouts match the configuration (IDs %s)", len(ids), joinInt64s(ids))
+	}
+}
+
+// matchingIDs returns the IDs of the checkouts matching where, highest first.
+func (m *checkoutMatch) matchingIDs(ctx context.Context, client *ghostwriterClient, where map[string]any) ([]int64, error) {
+	querycheckouts := fmt.Sprintf(`query QueryCheckouts ($where: %[1]s_bool_exp!) {
+		%[1]s(where: $where, order_by: {id: desc}) {
+			id
+		}
+	}`, m.table)
+	request := graphql.NewRequest(querycheckouts)
+	request.Var("where", where)
+	var respData map[string][]struct {
+		ID int64 `json:"id"`
+	}
+	if err := client.Run(ctx, request, &respData); err != nil {
+		return nil, err
+	}
+	ids := []int64{}
+	for _, checkout := range respData[m.table] {
+		ids = append(ids, checkout.ID)
+	}
+	return ids, nil
+}
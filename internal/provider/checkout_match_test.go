@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/machinebox/graphql"
+)
+
+func TestCheckoutMatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		created string
+		wantID  int64
+		wantErr string
+	}{
+		{
+			name:    "one new checkout",
+			created: `[{"id": 7}]`,
+			wantID:  7,
+		},
+		{
+			name:    "no new checkout",
+			created: `[]`,
+			wantErr: "no new checkout matches the configuration",
+		},
+		{
+			name:    "several new checkouts",
+			created: `[{"id": 8}, {"id": 7}]`,
+			wantErr: "2 new checkouts match the configuration (IDs 8, 7)",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				var body struct {
+					Query     string `json:"query"`
+					Variables struct {
+						Where map[string]any `json:"where"`
+					} `json:"variables"`
+				}
+				if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+					t.Errorf("decoding request: %s", err)
+				}
+				if !strings.Contains(body.Query, "domainCheckout_bool_exp!") {
+					t.Errorf("unexpected query %q", body.Query)
+				}
+				and, ok := body.Variables.Where["_and"].([]any)
+				if !ok {
+					// The existing checkouts with the same attributes, such as released ones
+					_, _ = w.Write([]byte(`{"data": {"domainCheckout": [{"id": 5}, {"id": 3}]}}`))
+					return
+				}
+				after, _ := json.Marshal(and[1])
+				if string(after) != `{"id":{"_gt":5}}` {
+					t.Errorf("expected only checkouts after ID 5 to be matched, got %s", after)
+				}
+				_, _ = w.Write([]byte(`{"data": {"domainCheckout": ` + test.created + `}}`))
+			}))
+			defer server.Close()
+
+			client := newGhostwriterClient(graphql.NewClient(server.URL))
+			match := newCheckoutMatch("domainCheckout", map[string]any{"domainId": 1, "note": ""})
+			if err := match.recordExisting(context.Background(), client); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			id, err := match.createdID(context.Background(), client)
+			if test.wantErr != "" {
+				if err == nil || err.Error() != test.wantErr {
+					t.Fatalf("expected error %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if id != test.wantID {
+				t.Errorf("expected checkout ID %d, got %d", test.wantID, id)
+			}
+		})
+	}
+}
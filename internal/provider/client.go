@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/machinebox/graphql"
+)
+
+// defaultReleaseStatus is the status checked out domains and servers are returned to when a
+// checkout is destroyed and release_status is not set.
+const defaultReleaseStatus = "Available"
+
+// defaultTimeout bounds the Ghostwriter requests of a resource operation when the resource has
+// no timeouts block.
+const defaultTimeout = 20 * time.Minute
+
+// ghostwriterClient is the provider data passed to data sources and resources. It embeds the
+// Ghostwriter graphql client and caches lookups that do not change while Terraform runs.
+type ghostwriterClient struct {
+	*graphql.Client
+
+	// username is the Ghostwriter user the client is authenticated as.
+	username string
+	// auditOplogID is the oplog changes are recorded in, or 0 when auditing is disabled.
+	auditOplogID int64
+
+	mu        sync.Mutex
+	lookupIDs map[string]int64
+}
+
+// newGhostwriterClient wraps a graphql client for use by data sources and resources.
+func newGhostwriterClient(client *graphql.Client) *ghostwriterClient {
+	return &ghostwriterClient{
+		Client:    client,
+		lookupIDs: map[string]int64{},
+	}
+}
+
+// lookupID returns the ID of the row of a Ghostwriter lookup table, such as domainStatus,
+// whose column equals name. Results are cached for the lifetime of the provider instance.
+func (c *ghostwriterClient) lookupID(ctx context.Context, table string, column string, name string) (int64, error) {
+	key := table + "." + column + "=" + name
+	c.mu.Lock()
+	id, ok := c.lookupIDs[key]
+	c.mu.Unlock()
+	if ok {
+		return id, nil
+	}
+
+	query := fmt.Sprintf(`query Lookup ($name: String) {
+		%s(where: {%s: {_eq: $name}}) {
+			id
+		}
+	}`, table, column)
+	request := graphql.NewRequest(query)
+	request.Var("name", name)
+	var respData map[string][]struct {
+		ID int64 `json:"id"`
+	}
+	if err := c.Run(ctx, request, &respData); err != nil {
+		return 0, fmt.Errorf("looking up %s %q: %w", table, name, err)
+	}
+	rows := respData[table]
+	if len(rows) != 1 {
+		return 0, fmt.Errorf("looking up %s %q: expected exactly one match, found %d", table, name, len(rows))
+	}
+
+	c.mu.Lock()
+	c.lookupIDs[key] = rows[0].ID
+	c.mu.Unlock()
+	return rows[0].ID, nil
+}
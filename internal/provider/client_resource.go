@@ -0,0 +1,461 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &clientResource{}
+	_ resource.ResourceWithConfigure   = &clientResource{}
+	_ resource.ResourceWithImportState = &clientResource{}
+)
+
+// NewclientResource is a helper function to simplify the provider implementation.
+func NewclientResource() resource.Resource {
+	return &clientResource{}
+}
+
+// clientResource is the resource implementation.
+type clientResource struct {
+	client *ghostwriterClient
+}
+
+// clientResourceModel maps the resource schema data.
+type clientResourceModel struct {
+	ID          types.Int64    `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	ShortName   types.String   `tfsdk:"short_name"`
+	CodeName    types.String   `tfsdk:"code_name"`
+	Address     types.String   `tfsdk:"address"`
+	Timezone    types.String   `tfsdk:"timezone"`
+	Note        types.String   `tfsdk:"note"`
+	ForceDelete types.Bool     `tfsdk:"force_delete"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *clientResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_client"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *clientResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *clientResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create a client in ghostwriter.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the client.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the client.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The full name of the client.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"short_name": schema.StringAttribute{
+				Description: "An abbreviated name to use for the client in reports.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(0, 255),
+				},
+			},
+			"code_name": schema.StringAttribute{
+				Description: "The codename of the client, used to refer to the client without revealing its name. Left unset when not provided.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"address": schema.StringAttribute{
+				Description: "The address of the client.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The timezone of the client, e.g. Europe/London. Default is America/Los_Angeles.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("America/Los_Angeles"),
+			},
+			"note": schema.StringAttribute{
+				Description: "Notes about the client.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"force_delete": schema.BoolAttribute{
+				Description: "If false, the client will not be deleted from the ghostwriter instance when not managed by terraform. If true, the client and its projects will be hard-deleted from the ghostwriter instance. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ImportState imports the resource state from Terraform state by ID or codename.
+func (r *clientResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	tflog.Debug(ctx, fmt.Sprintf("Importing client resource: %s", req.ID))
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		const queryclientcodename = `query QueryClient ($codename: String){
+			client(where: {codename: {_eq: $codename}}) {
+				id
+			}
+		}`
+		request := graphql.NewRequest(queryclientcodename)
+		request.Var("codename", req.ID)
+		var respData struct {
+			Client []ghostwriterClientRow `json:"client"`
+		}
+		if err := r.client.Run(ctx, request, &respData); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Ghostwriter Client",
+				"Could not look up client codename "+req.ID+": "+err.Error(),
+			)
+			return
+		}
+		if len(respData.Client) != 1 {
+			resp.Diagnostics.AddError(
+				"Error Importing Ghostwriter Client",
+				fmt.Sprintf("Expected one client with the ID or codename %s, found %d.", req.ID, len(respData.Client)),
+			)
+			return
+		}
+		id = respData.Client[0].ID
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_delete"), false)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *clientResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan clientResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const insertclient = `mutation InsertClient ($name: String, $short_name: String, $codename: String, $address: String, $timezone: String, $note: String){
+		insert_client(objects: {name: $name, shortName: $short_name, codename: $codename, address: $address, timezone: $timezone, note: $note}) {
+			returning {
+				id
+				name
+				shortName
+				codename
+				address
+				timezone
+				note
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Creating client: %v", plan))
+	request := graphql.NewRequest(insertclient)
+	request.Var("name", plan.Name.ValueString())
+	request.Var("short_name", plan.ShortName.ValueString())
+	request.Var("codename", nullableString(plan.CodeName))
+	request.Var("address", plan.Address.ValueString())
+	request.Var("timezone", plan.Timezone.ValueString())
+	request.Var("note", plan.Note.ValueString())
+	var respData struct {
+		InsertClient mutationResponse[ghostwriterClientRow] `json:"insert_client"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating client",
+			"Could not create client, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	clients := respData.InsertClient.Returning
+	if len(clients) == 1 {
+		plan.setClient(clients[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error creating client",
+			"Could not create client: Client not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_client", tftypes.Value{}, resp.State.Raw)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *clientResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state clientResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const queryclient = `query QueryClient ($id: bigint){
+		client(where: {id: {_eq: $id}}) {
+			id
+			name
+			shortName
+			codename
+			address
+			timezone
+			note
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Reading client: %v", state.ID))
+	request := graphql.NewRequest(queryclient)
+	request.Var("id", state.ID.ValueInt64())
+	var respData struct {
+		Client []ghostwriterClientRow `json:"client"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Client",
+			"Could not read Ghostwriter client ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	clients := respData.Client
+	if len(clients) == 1 {
+		state.setClient(clients[0])
+
+		// Set refreshed state
+		diags = resp.State.Set(ctx, &state)
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter client ID %v not found, removing from state", state.ID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *clientResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan clientResourceModel
+	var state clientResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(stateDiags...)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const updateclient = `mutation UpdateClient ($id: bigint, $name: String, $short_name: String, $codename: String, $address: String, $timezone: String, $note: String){
+		update_client(where: {id: {_eq: $id}}, _set: {name: $name, shortName: $short_name, codename: $codename, address: $address, timezone: $timezone, note: $note}) {
+			returning {
+				id
+				name
+				shortName
+				codename
+				address
+				timezone
+				note
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Updating client: %v", plan))
+	request := graphql.NewRequest(updateclient)
+	request.Var("id", state.ID.ValueInt64())
+	request.Var("name", plan.Name.ValueString())
+	request.Var("short_name", plan.ShortName.ValueString())
+	request.Var("codename", nullableString(plan.CodeName))
+	request.Var("address", plan.Address.ValueString())
+	request.Var("timezone", plan.Timezone.ValueString())
+	request.Var("note", plan.Note.ValueString())
+	var respData struct {
+		UpdateClient mutationResponse[ghostwriterClientRow] `json:"update_client"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Client",
+			"Could not update client ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_clients := respData.UpdateClient.Returning
+	if len(updated_clients) == 1 {
+		plan.setClient(updated_clients[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Client",
+			"Could not update client ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": client not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_client", req.State.Raw, resp.State.Raw)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *clientResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state clientResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	if state.ForceDelete.ValueBool() {
+		// Generate API request body from plan
+		const deleteclient = `mutation DeleteClient ($id: bigint){
+			delete_client(where: {id: {_eq: $id}}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		request := graphql.NewRequest(deleteclient)
+		request.Var("id", state.ID.ValueInt64())
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Client", "client ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+	} else {
+		tflog.Info(ctx, "Cowardly refusing to delete client. The client record will remain in ghostwriter. Set force_delete to true to delete client.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_client", req.State.Raw, tftypes.Value{})...)
+}
+
+// setClient copies a client row returned by Ghostwriter into the model.
+func (m *clientResourceModel) setClient(client ghostwriterClientRow) {
+	m.ID = types.Int64Value(client.ID)
+	m.Name = stringValueOrEmpty(client.Name)
+	m.ShortName = stringValueOrEmpty(client.ShortName)
+	m.CodeName = stringValueOrEmpty(client.Codename)
+	m.Address = stringValueOrEmpty(client.Address)
+	m.Timezone = stringValueOrEmpty(client.Timezone)
+	m.Note = stringValueOrEmpty(client.Note)
+}
@@ -0,0 +1,71 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestClientResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_client" "test" {
+  name = "Test Client"
+  short_name = "TC"
+  code_name = "TestClientCodename"
+  timezone = "Europe/London"
+  force_delete = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "name", "Test Client"),
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "short_name", "TC"),
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "code_name", "TestClientCodename"),
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "timezone", "Europe/London"),
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "force_delete", "true"),
+					resource.TestCheckResourceAttrSet("ghostwriter_client.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_client.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ghostwriter_client.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_delete", "last_updated"},
+			},
+			// ImportState by codename testing
+			{
+				ResourceName:            "ghostwriter_client.test",
+				ImportState:             true,
+				ImportStateId:           "TestClientCodename",
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_delete", "last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_client" "test" {
+  name = "Test Updated Client"
+  short_name = "TC"
+  code_name = "TestClientCodename"
+  timezone = "Europe/London"
+  note = "Updated by terraform"
+  force_delete = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "name", "Test Updated Client"),
+					resource.TestCheckResourceAttr("ghostwriter_client.test", "note", "Updated by terraform"),
+					resource.TestCheckResourceAttrSet("ghostwriter_client.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_client.test", "last_updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -7,16 +7,21 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
@@ -25,6 +30,7 @@ import (
 var (
 	_ resource.Resource                = &cloudserverResource{}
 	_ resource.ResourceWithConfigure   = &cloudserverResource{}
+	_ resource.ResourceWithModifyPlan  = &cloudserverResource{}
 	_ resource.ResourceWithImportState = &cloudserverResource{}
 )
 
@@ -35,7 +41,7 @@ func NewcloudserverResource() resource.Resource {
 
 // cloudserverResource is the resource implementation.
 type cloudserverResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
@@ -43,14 +49,18 @@ type cloudserverResourceModel struct {
 	ID               types.Int64    `tfsdk:"id"`
 	Name             types.String   `tfsdk:"name"`
 	ServerProviderID types.Int64    `tfsdk:"server_provider_id"`
+	ServerProvider   types.String   `tfsdk:"server_provider"`
 	ActivityTypeId   types.Int64    `tfsdk:"activity_type_id"`
+	ActivityType     types.String   `tfsdk:"activity_type"`
 	IpAddress        types.String   `tfsdk:"ip_address"`
 	AuxAddress       []types.String `tfsdk:"aux_address"`
 	ProjectID        types.Int64    `tfsdk:"project_id"`
 	Note             types.String   `tfsdk:"note"`
 	ServerRoleId     types.Int64    `tfsdk:"server_role_id"`
+	ServerRole       types.String   `tfsdk:"server_role"`
 	ForceDelete      types.Bool     `tfsdk:"force_delete"`
 	LastUpdated      types.String   `tfsdk:"last_updated"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -66,12 +76,12 @@ func (r *cloudserverResource) Configure(_ context.Context, req resource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -81,13 +91,16 @@ func (r *cloudserverResource) Configure(_ context.Context, req resource.Configur
 }
 
 // Schema defines the schema for the resource.
-func (r *cloudserverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *cloudserverResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Add a cloud server to ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the cloud server.",
@@ -100,12 +113,34 @@ func (r *cloudserverResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Default:     stringdefault.StaticString(""),
 			},
 			"server_provider_id": schema.Int64Attribute{
-				Description: "The identifier of the server hosting provider.",
-				Required:    true,
+				Description: "The identifier of the server hosting provider. Exactly one of server_provider_id or server_provider must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("server_provider")),
+				},
+			},
+			"server_provider": schema.StringAttribute{
+				Description: "The name of the server hosting provider, resolved to server_provider_id at plan time. e.g. Amazon Web Services",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"activity_type_id": schema.Int64Attribute{
-				Description: "How this VPS will be used.",
-				Required:    true,
+				Description: "How this VPS will be used. Exactly one of activity_type_id or activity_type must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("activity_type")),
+				},
+			},
+			"activity_type": schema.StringAttribute{
+				Description: "The name of the activity type being performed, resolved to activity_type_id at plan time. e.g. Command and Control",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"ip_address": schema.StringAttribute{
 				Description: "The servers IP address.",
@@ -139,8 +174,19 @@ func (r *cloudserverResource) Schema(_ context.Context, _ resource.SchemaRequest
 				},
 			},
 			"server_role_id": schema.Int64Attribute{
-				Description: "The role of the server.",
-				Required:    true,
+				Description: "The role of the server. Exactly one of server_role_id or server_role must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("server_role")),
+				},
+			},
+			"server_role": schema.StringAttribute{
+				Description: "The name of the role of the server, resolved to server_role_id at plan time. e.g. Team Server / C2 Server",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"force_delete": schema.BoolAttribute{
 				Description: "If false, will not be deleted from the ghostwriter instance when not managed by terraform. If true, the server will be hard-deleted from the ghostwriter instance. Default is false.",
@@ -149,7 +195,24 @@ func (r *cloudserverResource) Schema(_ context.Context, _ resource.SchemaRequest
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan resolves the lookup table references configured by name.
+func (r *cloudserverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
 	}
+	resp.Diagnostics.Append(r.client.resolveReferences(ctx, &resp.Plan, serverProviderReference, activityTypeReference, serverRoleReference)...)
 }
 
 // ImportState imports the resource state from Terraform state.
@@ -178,6 +241,14 @@ func (r *cloudserverResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const insertcloudserver = `mutation InsertCloudServer ($name: String, $server_provider_id: bigint, $activity_type_id: bigint, $ip: inet, $aux_address: [inet!], $project_id: bigint, $note: String, $server_role_id: bigint) {
 		insert_cloudServer(objects: {name: $name, serverProviderId: $server_provider_id, activityTypeId: $activity_type_id, ipAddress: $ip, auxAddress: $aux_address, projectId: $project_id, note: $note, serverRoleId: $server_role_id}) {
@@ -208,7 +279,9 @@ func (r *cloudserverResource) Create(ctx context.Context, req resource.CreateReq
 	request.Var("project_id", plan.ProjectID.ValueInt64())
 	request.Var("note", plan.Note.ValueString())
 	request.Var("server_role_id", plan.ServerRoleId.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		InsertCloudServer mutationResponse[ghostwriterCloudServer] `json:"insert_cloudServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating cloud server",
@@ -217,26 +290,19 @@ func (r *cloudserverResource) Create(ctx context.Context, req resource.CreateReq
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	cloud_servers := respData["insert_cloudServer"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	cloud_servers := respData.InsertCloudServer.Returning
 	if len(cloud_servers) == 1 {
-		cloud_server := cloud_servers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(cloud_server["id"].(float64)))
-		plan.Name = types.StringValue(cloud_server["name"].(string))
-		plan.ServerProviderID = types.Int64Value(int64(cloud_server["serverProviderId"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(cloud_server["activityTypeId"].(float64)))
-		plan.IpAddress = types.StringValue(cloud_server["ipAddress"].(string))
-		aux_address, ok := cloud_server["auxAddress"].([]interface{})
-		aux_address_list := []types.String{}
-		if ok {
-			for _, address := range aux_address {
-				aux_address_list = append(aux_address_list, types.StringValue(address.(string)))
-			}
-		}
-		plan.AuxAddress = aux_address_list
-		plan.ProjectID = types.Int64Value(int64(cloud_server["projectId"].(float64)))
-		plan.Note = types.StringValue(cloud_server["note"].(string))
-		plan.ServerRoleId = types.Int64Value(int64(cloud_server["serverRoleId"].(float64)))
+		cloud_server := cloud_servers[0]
+		plan.ID = types.Int64Value(cloud_server.ID)
+		plan.Name = stringValueOrEmpty(cloud_server.Name)
+		plan.ServerProviderID = int64ValueOrZero(cloud_server.ServerProviderID)
+		plan.ActivityTypeId = int64ValueOrZero(cloud_server.ActivityTypeID)
+		plan.IpAddress = stringValueOrEmpty(cloud_server.IpAddress)
+		plan.AuxAddress = stringListValue(cloud_server.AuxAddress)
+		plan.ProjectID = int64ValueOrZero(cloud_server.ProjectID)
+		plan.Note = stringValueOrEmpty(cloud_server.Note)
+		plan.ServerRoleId = int64ValueOrZero(cloud_server.ServerRoleID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -252,6 +318,7 @@ func (r *cloudserverResource) Create(ctx context.Context, req resource.CreateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_cloud_server", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -264,6 +331,14 @@ func (r *cloudserverResource) Read(ctx context.Context, req resource.ReadRequest
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const querycloudserver = `query CloudServer ($id: bigint){
 		cloudServer(where: {id: {_eq: $id}}) {
@@ -281,39 +356,36 @@ func (r *cloudserverResource) Read(ctx context.Context, req resource.ReadRequest
 	tflog.Debug(ctx, fmt.Sprintf("Reading cloud server: %v", state.ID))
 	request := graphql.NewRequest(querycloudserver)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		CloudServer []ghostwriterCloudServer `json:"cloudServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter cloud server ID : %v", state.ID))
-		respData = map[string]interface{}{
-			"cloudServer": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Cloud Server",
+			"Could not read Ghostwriter cloud server ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
 	// Overwrite items with refreshed state
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	cloud_servers := respData["cloudServer"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	cloud_servers := respData.CloudServer
 	if len(cloud_servers) == 1 {
-		cloud_server := cloud_servers[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(cloud_server["id"].(float64)))
-		state.Name = types.StringValue(cloud_server["name"].(string))
-		state.ServerProviderID = types.Int64Value(int64(cloud_server["serverProviderId"].(float64)))
-		state.ActivityTypeId = types.Int64Value(int64(cloud_server["activityTypeId"].(float64)))
-		state.IpAddress = types.StringValue(cloud_server["ipAddress"].(string))
-		aux_address, ok := cloud_server["auxAddress"].([]interface{})
-		aux_address_list := []types.String{}
-		if ok {
-			for _, address := range aux_address {
-				aux_address_list = append(aux_address_list, types.StringValue(address.(string)))
-			}
-		}
-		state.AuxAddress = aux_address_list
-		state.ProjectID = types.Int64Value(int64(cloud_server["projectId"].(float64)))
-		state.Note = types.StringValue(cloud_server["note"].(string))
-		state.ServerRoleId = types.Int64Value(int64(cloud_server["serverRoleId"].(float64)))
+		cloud_server := cloud_servers[0]
+		state.ID = types.Int64Value(cloud_server.ID)
+		state.Name = stringValueOrEmpty(cloud_server.Name)
+		state.ServerProviderID = int64ValueOrZero(cloud_server.ServerProviderID)
+		state.ActivityTypeId = int64ValueOrZero(cloud_server.ActivityTypeID)
+		state.IpAddress = stringValueOrEmpty(cloud_server.IpAddress)
+		state.AuxAddress = stringListValue(cloud_server.AuxAddress)
+		state.ProjectID = int64ValueOrZero(cloud_server.ProjectID)
+		state.Note = stringValueOrEmpty(cloud_server.Note)
+		state.ServerRoleId = int64ValueOrZero(cloud_server.ServerRoleID)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter cloud server ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -337,6 +409,14 @@ func (r *cloudserverResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updatecloudserver = `mutation UpdateCloudServer ($id: bigint, $name: String, $server_provider_id: bigint, $activity_type_id: bigint, $ip: inet, $aux_address: [inet!], $project_id: bigint, $note: String, $server_role_id: bigint) {
 		update_cloudServer(where: {id: {_eq: $id}}, _set: {name: $name, serverProviderId: $server_provider_id, activityTypeId: $activity_type_id, ipAddress: $ip, auxAddress: $aux_address, projectId: $project_id, note: $note, serverRoleId: $server_role_id}) {
@@ -368,7 +448,9 @@ func (r *cloudserverResource) Update(ctx context.Context, req resource.UpdateReq
 	request.Var("project_id", plan.ProjectID.ValueInt64())
 	request.Var("note", plan.Note.ValueString())
 	request.Var("server_role_id", plan.ServerRoleId.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateCloudServer mutationResponse[ghostwriterCloudServer] `json:"update_cloudServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Cloud Server",
@@ -377,26 +459,19 @@ func (r *cloudserverResource) Update(ctx context.Context, req resource.UpdateReq
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	cloud_servers := respData["update_cloudServer"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	cloud_servers := respData.UpdateCloudServer.Returning
 	if len(cloud_servers) == 1 {
-		cloud_server := cloud_servers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(cloud_server["id"].(float64)))
-		plan.Name = types.StringValue(cloud_server["name"].(string))
-		plan.ServerProviderID = types.Int64Value(int64(cloud_server["serverProviderId"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(cloud_server["activityTypeId"].(float64)))
-		plan.IpAddress = types.StringValue(cloud_server["ipAddress"].(string))
-		aux_address, ok := cloud_server["auxAddress"].([]interface{})
-		aux_address_list := []types.String{}
-		if ok {
-			for _, address := range aux_address {
-				aux_address_list = append(aux_address_list, types.StringValue(address.(string)))
-			}
-		}
-		plan.AuxAddress = aux_address_list
-		plan.ProjectID = types.Int64Value(int64(cloud_server["projectId"].(float64)))
-		plan.Note = types.StringValue(cloud_server["note"].(string))
-		plan.ServerRoleId = types.Int64Value(int64(cloud_server["serverRoleId"].(float64)))
+		cloud_server := cloud_servers[0]
+		plan.ID = types.Int64Value(cloud_server.ID)
+		plan.Name = stringValueOrEmpty(cloud_server.Name)
+		plan.ServerProviderID = int64ValueOrZero(cloud_server.ServerProviderID)
+		plan.ActivityTypeId = int64ValueOrZero(cloud_server.ActivityTypeID)
+		plan.IpAddress = stringValueOrEmpty(cloud_server.IpAddress)
+		plan.AuxAddress = stringListValue(cloud_server.AuxAddress)
+		plan.ProjectID = int64ValueOrZero(cloud_server.ProjectID)
+		plan.Note = stringValueOrEmpty(cloud_server.Note)
+		plan.ServerRoleId = int64ValueOrZero(cloud_server.ServerRoleID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -412,6 +487,7 @@ func (r *cloudserverResource) Update(ctx context.Context, req resource.UpdateReq
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_cloud_server", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -424,10 +500,19 @@ func (r *cloudserverResource) Delete(ctx context.Context, req resource.DeleteReq
 		return
 	}
 
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
 	if state.ForceDelete.ValueBool() {
 		// Generate API request body from plan
 		const deletecloudserver = `mutation DeleteCloudServer ($id: bigint){
 			delete_cloudServer(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -435,12 +520,12 @@ func (r *cloudserverResource) Delete(ctx context.Context, req resource.DeleteReq
 		}`
 		request := graphql.NewRequest(deletecloudserver)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
-			return
-		}
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Cloud Server", "cloud server ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
 	} else {
 		tflog.Info(ctx, "Cowardly refusing to delete cloud server. Cloud Server expiration will be managed by ghostwriter. Set force_delete to true to delete cloud server.")
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_cloud_server", req.State.Raw, tftypes.Value{})...)
 }
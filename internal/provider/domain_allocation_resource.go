@@ -0,0 +1,778 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/boolplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &domainAllocationResource{}
+	_ resource.ResourceWithConfigure   = &domainAllocationResource{}
+	_ resource.ResourceWithModifyPlan  = &domainAllocationResource{}
+	_ resource.ResourceWithImportState = &domainAllocationResource{}
+)
+
+// NewdomainAllocationResource is a helper function to simplify the provider implementation.
+func NewdomainAllocationResource() resource.Resource {
+	return &domainAllocationResource{}
+}
+
+// domainAllocationResource is the resource implementation.
+type domainAllocationResource struct {
+	client *ghostwriterClient
+}
+
+// domainAllocationResourceModel maps the resource schema data.
+type domainAllocationResourceModel struct {
+	ID                     types.Int64    `tfsdk:"id"`
+	ProjectId              types.Int64    `tfsdk:"project_id"`
+	ActivityTypeId         types.Int64    `tfsdk:"activity_type_id"`
+	ActivityType           types.String   `tfsdk:"activity_type"`
+	StartDate              types.String   `tfsdk:"start_date"`
+	EndDate                types.String   `tfsdk:"end_date"`
+	Note                   types.String   `tfsdk:"note"`
+	DomainCount            types.Int64    `tfsdk:"domain_count"`
+	MinAgeDays             types.Int64    `tfsdk:"min_age_days"`
+	Category               types.String   `tfsdk:"category"`
+	ExpiresAfterProjectEnd types.Bool     `tfsdk:"expires_after_project_end"`
+	DomainIds              types.List     `tfsdk:"domain_ids"`
+	DomainNames            types.List     `tfsdk:"domain_names"`
+	CheckoutIds            types.List     `tfsdk:"checkout_ids"`
+	ForceDelete            types.Bool     `tfsdk:"force_delete"`
+	ReleaseStatus          types.String   `tfsdk:"release_status"`
+	LastUpdated            types.String   `tfsdk:"last_updated"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *domainAllocationResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain_allocation"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *domainAllocationResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *domainAllocationResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Pick available domains from the ghostwriter pool and check them out to a project. The domains are chosen once, when the resource is created, and kept until it is replaced.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "Placeholder identifier attribute",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the domain allocation.",
+				Computed:    true,
+			},
+			"project_id": schema.Int64Attribute{
+				Description: "The unique identifier of the project the domains should be checked out to.",
+				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"activity_type_id": schema.Int64Attribute{
+				Description: "The unique identifier of the activity type being performed. Exactly one of activity_type_id or activity_type must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("activity_type")),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"activity_type": schema.StringAttribute{
+				Description: "The name of the activity type being performed, resolved to activity_type_id at plan time. e.g. Command and Control",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Description: "The start date of the checkouts. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 256),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Description: "The end date of the checkouts. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 256),
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"note": schema.StringAttribute{
+				Description: "Project-related notes recorded on every checkout.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(0, 256),
+				},
+			},
+			"domain_count": schema.Int64Attribute{
+				Description: "The number of domains to allocate. Default is 1.",
+				Optional:    true,
+				Computed:    true,
+				Default:     int64default.StaticInt64(1),
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"min_age_days": schema.Int64Attribute{
+				Description: "Only allocate domains registered at least this many days ago.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
+			},
+			"category": schema.StringAttribute{
+				Description: "Only allocate domains whose categorization contains this text, ignoring case. e.g. Business",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.RequiresReplace(),
+				},
+			},
+			"expires_after_project_end": schema.BoolAttribute{
+				Description: "If true, only allocate domains that do not expire before the end date of the project. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+				PlanModifiers: []planmodifier.Bool{
+					boolplanmodifier.RequiresReplace(),
+				},
+			},
+			"domain_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the allocated domains.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"domain_names": schema.ListAttribute{
+				Description: "The names of the allocated domains.",
+				ElementType: types.StringType,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"checkout_ids": schema.ListAttribute{
+				Description: "The unique identifiers of the domain checkouts, in the same order as domain_ids.",
+				ElementType: types.Int64Type,
+				Computed:    true,
+				PlanModifiers: []planmodifier.List{
+					listplanmodifier.UseStateForUnknown(),
+				},
+			},
+			"force_delete": schema.BoolAttribute{
+				Description: "If false, the domain checkouts will not be deleted but the domains will be released and the records will remain. If true, the domain checkout records will be hard-deleted from the ghostwriter instance. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"release_status": schema.StringAttribute{
+				Description: "The name of the domain status the domains are set to when the allocation is destroyed. Default is Available.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultReleaseStatus),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan resolves the lookup table references configured by name and warns when the checkouts
+// would end after the project.
+func (r *domainAllocationResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.client.resolveReferences(ctx, &resp.Plan, activityTypeReference)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan domainAllocationResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	var state domainAllocationResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if req.State.Raw.IsNull() || !plan.ProjectId.Equal(state.ProjectId) || !plan.EndDate.Equal(state.EndDate) {
+		resp.Diagnostics.Append(r.client.checkCheckoutProject(ctx, plan.ProjectId, plan.EndDate)...)
+	}
+}
+
+// ImportState imports the resource state from the comma separated IDs of its domain checkouts.
+func (r *domainAllocationResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	tflog.Debug(ctx, fmt.Sprintf("Importing domain allocation resource checkout IDs: %s", req.ID))
+	checkout_ids := []int64{}
+	for _, field := range strings.Split(req.ID, ",") {
+		checkout_id, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Parsing Import ID",
+				"Could not parse import ID, expected comma separated domain checkout IDs: "+err.Error(),
+			)
+			return
+		}
+		checkout_ids = append(checkout_ids, checkout_id)
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), checkout_ids[0])...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("checkout_ids"), checkout_ids)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("expires_after_project_end"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_delete"), false)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("release_status"), defaultReleaseStatus)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *domainAllocationResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan domainAllocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	// Build the filter for the candidate domains
+	available_id, err := r.client.lookupID(ctx, "domainStatus", "domainStatus", defaultReleaseStatus)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Allocating Ghostwriter Domains",
+			"Could not resolve the domain status "+defaultReleaseStatus+": "+err.Error(),
+		)
+		return
+	}
+	where := map[string]any{
+		"domainStatusId": map[string]any{"_eq": available_id},
+	}
+	if !plan.MinAgeDays.IsNull() {
+		registered_before := time.Now().AddDate(0, 0, -int(plan.MinAgeDays.ValueInt64()))
+		where["creation"] = map[string]any{"_lte": registered_before.Format(time.DateOnly)}
+	}
+	if !plan.Category.IsNull() {
+		where["categorization"] = map[string]any{
+			"_cast": map[string]any{
+				"String": map[string]any{"_ilike": "%" + plan.Category.ValueString() + "%"},
+			},
+		}
+	}
+	if plan.ExpiresAfterProjectEnd.ValueBool() {
+		project, project_diags := r.client.readProject(ctx, plan.ProjectId.ValueInt64())
+		resp.Diagnostics.Append(project_diags...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		if project.EndDate == nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("project_id"),
+				"Error Reading Ghostwriter Project",
+				"Could not find the end date of Ghostwriter project ID "+strconv.FormatInt(plan.ProjectId.ValueInt64(), 10)+".",
+			)
+			return
+		}
+		where["expiration"] = map[string]any{"_gte": *project.EndDate}
+	}
+
+	// Oldest domains are allocated first, as they are the most likely to be trusted
+	const querycandidates = `query QueryAvailableDomains ($where: domain_bool_exp!, $limit: Int!) {
+		domain(where: $where, order_by: [{creation: asc}, {id: asc}], limit: $limit) {
+			id
+			name
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Query available domains matching: %v", where))
+	request := graphql.NewRequest(querycandidates)
+	request.Var("where", where)
+	request.Var("limit", plan.DomainCount.ValueInt64())
+	var candidatesResp struct {
+		Domain []ghostwriterDomain `json:"domain"`
+	}
+	if err := r.client.Run(ctx, request, &candidatesResp); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domains",
+			"Could not read available Ghostwriter domains: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(candidatesResp)))
+
+	candidates := candidatesResp.Domain
+	if int64(len(candidates)) < plan.DomainCount.ValueInt64() {
+		resp.Diagnostics.AddError(
+			"Insufficient Available Ghostwriter Domains",
+			fmt.Sprintf("%d domains were requested, but only %d available domains match the constraints.", plan.DomainCount.ValueInt64(), len(candidates)),
+		)
+		return
+	}
+
+	// Check out the domains one at a time. If a checkout fails, the domains already checked out
+	// are saved to state so Terraform releases them when the tainted resource is replaced.
+	domain_ids := []int64{}
+	domain_names := []string{}
+	checkout_ids := []int64{}
+	for _, domain := range candidates {
+		checkout_id, err := r.checkoutDomain(ctx, plan, domain.ID)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error checking out domain",
+				"Could not checkout domain ID "+strconv.FormatInt(domain.ID, 10)+": "+err.Error(),
+			)
+			break
+		}
+		domain_ids = append(domain_ids, domain.ID)
+		domain_names = append(domain_names, stringValueOrEmpty(domain.Name).ValueString())
+		checkout_ids = append(checkout_ids, checkout_id)
+	}
+	if len(checkout_ids) == 0 {
+		return
+	}
+
+	plan.ID = types.Int64Value(checkout_ids[0])
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+	resp.Diagnostics.Append(plan.setAllocation(ctx, domain_ids, domain_names, checkout_ids)...)
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_domain_allocation", tftypes.Value{}, resp.State.Raw)...)
+}
+
+// checkoutDomain checks out a single domain with the attributes of the allocation and returns the
+// ID of the checkout that was created.
+func (r *domainAllocationResource) checkoutDomain(ctx context.Context, plan domainAllocationResourceModel, domain_id int64) (int64, error) {
+	// Record the checkouts that already match, so the one created below can be told apart from
+	// older checkouts with the same attributes
+	match := newCheckoutMatch("domainCheckout", map[string]any{
+		"domainId":       domain_id,
+		"projectId":      plan.ProjectId.ValueInt64(),
+		"activityTypeId": plan.ActivityTypeId.ValueInt64(),
+		"startDate":      plan.StartDate.ValueString(),
+		"endDate":        plan.EndDate.ValueString(),
+		"note":           plan.Note.ValueString(),
+	})
+	if err := match.recordExisting(ctx, r.client); err != nil {
+		return 0, fmt.Errorf("reading the existing checkouts of the domain: %w", err)
+	}
+
+	const checkoutdomain = `mutation checkoutDomain ($activity_type_id: Int!, $domain_id: Int!, $project_id: Int!, $note: String, $start_date: date!, $end_date: date!) {
+		checkoutDomain(activityTypeId: $activity_type_id, domainId: $domain_id, projectId: $project_id, note: $note, startDate: $start_date, endDate: $end_date) {
+			result
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Checking out domain ID: %v", domain_id))
+	request := graphql.NewRequest(checkoutdomain)
+	request.Var("activity_type_id", plan.ActivityTypeId.ValueInt64())
+	request.Var("domain_id", domain_id)
+	request.Var("project_id", plan.ProjectId.ValueInt64())
+	request.Var("note", plan.Note.ValueString())
+	request.Var("start_date", plan.StartDate.ValueString())
+	request.Var("end_date", plan.EndDate.ValueString())
+	var respData struct {
+		CheckoutDomain checkoutResponse `json:"checkoutDomain"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		return 0, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	if checkout_id, ok := respData.CheckoutDomain.checkoutID(); ok {
+		return checkout_id, nil
+	}
+
+	// Older Ghostwriter instances do not return the checkout, so the new matching one is used
+	checkout_id, err := match.createdID(ctx, r.client)
+	if err != nil {
+		return 0, fmt.Errorf("the domain was checked out but the checkout could not be identified: %w", err)
+	}
+	return checkout_id, nil
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *domainAllocationResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state domainAllocationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
+	var checkout_ids []int64
+	resp.Diagnostics.Append(state.CheckoutIds.ElementsAs(ctx, &checkout_ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	const querydomaincheckouts = `query QueryDomainCheckouts ($ids: [bigint!]) {
+		domainCheckout(where: {id: {_in: $ids}}, order_by: {id: asc}) {
+			id
+			domainId
+			projectId
+			activityTypeId
+			startDate
+			endDate
+			note
+			domain {
+				name
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Query domain checkout IDs: %v", checkout_ids))
+	request := graphql.NewRequest(querydomaincheckouts)
+	request.Var("ids", checkout_ids)
+	var respData struct {
+		DomainCheckout []struct {
+			ghostwriterDomainCheckout
+			Domain *struct {
+				Name *string `json:"name"`
+			} `json:"domain"`
+		} `json:"domainCheckout"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain Allocation",
+			"Could not read Ghostwriter domain allocation ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+
+	domain_checkouts := respData.DomainCheckout
+	if len(domain_checkouts) == 0 {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter domain allocation ID %v not found, removing from state", state.ID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	domain_ids := []int64{}
+	domain_names := []string{}
+	found := map[int64]bool{}
+	for _, checkout := range domain_checkouts {
+		found[checkout.ID] = true
+	}
+	missing_ids := []int64{}
+	for _, checkout_id := range checkout_ids {
+		if !found[checkout_id] {
+			missing_ids = append(missing_ids, checkout_id)
+		}
+	}
+	// domain_count requires replacement, so shrinking it makes the next apply replace the allocation
+	if len(missing_ids) > 0 {
+		resp.Diagnostics.AddWarning(
+			"Ghostwriter Domain Allocation Checkouts Missing",
+			"The domain checkout IDs "+joinInt64s(missing_ids)+" of Ghostwriter domain allocation ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+" no longer exist. The allocation will be replaced to restore its domain_count.",
+		)
+	}
+	checkout_ids = []int64{}
+	for _, checkout := range domain_checkouts {
+		domain_ids = append(domain_ids, int64ValueOrZero(checkout.DomainID).ValueInt64())
+		if checkout.Domain != nil {
+			domain_names = append(domain_names, stringValueOrEmpty(checkout.Domain.Name).ValueString())
+		} else {
+			domain_names = append(domain_names, "")
+		}
+		checkout_ids = append(checkout_ids, checkout.ID)
+	}
+	state.ProjectId = int64ValueOrZero(domain_checkouts[0].ProjectID)
+	state.ActivityTypeId = int64ValueOrZero(domain_checkouts[0].ActivityTypeID)
+	state.StartDate = stringValueOrEmpty(domain_checkouts[0].StartDate)
+	state.EndDate = stringValueOrEmpty(domain_checkouts[0].EndDate)
+	state.Note = stringValueOrEmpty(domain_checkouts[0].Note)
+	state.DomainCount = types.Int64Value(int64(len(domain_checkouts)))
+	resp.Diagnostics.Append(state.setAllocation(ctx, domain_ids, domain_names, checkout_ids)...)
+
+	// Set refreshed state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *domainAllocationResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan domainAllocationResourceModel
+	var state domainAllocationResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(stateDiags...)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
+	var checkout_ids []int64
+	resp.Diagnostics.Append(state.CheckoutIds.ElementsAs(ctx, &checkout_ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Every other attribute of the checkouts requires replacement, so only the note is updated
+	const updatedomaincheckouts = `mutation UpdateDomainCheckouts ($ids: [bigint!], $note: String) {
+		update_domainCheckout(where: {id: {_in: $ids}}, _set: {note: $note}) {
+			affected_rows
+			returning {
+				id
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Updating domain allocation: %v", plan))
+	request := graphql.NewRequest(updatedomaincheckouts)
+	request.Var("ids", checkout_ids)
+	request.Var("note", plan.Note.ValueString())
+	var respData struct {
+		UpdateDomainCheckout mutationResponse[ghostwriterDomainCheckout] `json:"update_domainCheckout"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Domain Allocation",
+			"Could not update domain allocation ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+
+	if respData.UpdateDomainCheckout.AffectedRows != int64(len(checkout_ids)) {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Domain Allocation",
+			fmt.Sprintf("Could not update domain allocation ID %d: updated %d of %d checkouts", state.ID.ValueInt64(), respData.UpdateDomainCheckout.AffectedRows, len(checkout_ids)),
+		)
+		return
+	}
+	plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+	// Set state to fully populated data
+	diags = resp.State.Set(ctx, plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_domain_allocation", req.State.Raw, resp.State.Raw)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *domainAllocationResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state domainAllocationResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	var checkout_ids []int64
+	var domain_ids []int64
+	resp.Diagnostics.Append(state.CheckoutIds.ElementsAs(ctx, &checkout_ids, false)...)
+	resp.Diagnostics.Append(state.DomainIds.ElementsAs(ctx, &domain_ids, false)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	target := "domain allocation ID " + strconv.FormatInt(state.ID.ValueInt64(), 10)
+
+	audit_action := "release"
+	if state.ForceDelete.ValueBool() {
+		audit_action = "delete"
+		// Generate API request body from plan
+		const deletedomaincheckouts = `mutation DeleteDomainCheckouts ($ids: [bigint!]) {
+			delete_domainCheckout(where: {id: {_in: $ids}}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		tflog.Debug(ctx, fmt.Sprintf("Deleting domain checkouts: %v", checkout_ids))
+		request := graphql.NewRequest(deletedomaincheckouts)
+		request.Var("ids", checkout_ids)
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Domain Allocation", target)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	} else {
+		tflog.Info(ctx, "Cowardly refusing to delete domain checkouts. Releasing domains to the ghostwriter pool and the domain checkout records will remain. Set force_delete to true to delete domain checkout records.")
+	}
+	// Generate API request body from plan
+	const releasedomains = `mutation UpdateDomains ($ids: [bigint!], $status_id: bigint) {
+		update_domain(where: {id: {_in: $ids}}, _set: {domainStatusId: $status_id}) {
+			affected_rows
+			returning {
+				id
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Releasing domains to the pool: %v", domain_ids))
+	release_status := state.ReleaseStatus.ValueString()
+	if release_status == "" {
+		release_status = defaultReleaseStatus
+	}
+	status_id, err := r.client.lookupID(ctx, "domainStatus", "domainStatus", release_status)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("release_status"),
+			"Error Releasing Ghostwriter Domains",
+			"Could not resolve the domain status "+release_status+": "+err.Error(),
+		)
+		return
+	}
+	request := graphql.NewRequest(releasedomains)
+	request.Var("ids", domain_ids)
+	request.Var("status_id", status_id)
+	resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "release", "Error Releasing Ghostwriter Domains", "domain IDs "+joinInt64s(domain_ids))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, audit_action, "ghostwriter_domain_allocation", req.State.Raw, tftypes.Value{})...)
+}
+
+// setAllocation stores the allocated domains and their checkouts in the model.
+func (m *domainAllocationResourceModel) setAllocation(ctx context.Context, domain_ids []int64, domain_names []string, checkout_ids []int64) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var list_diags diag.Diagnostics
+	m.DomainIds, list_diags = types.ListValueFrom(ctx, types.Int64Type, domain_ids)
+	diags.Append(list_diags...)
+	m.DomainNames, list_diags = types.ListValueFrom(ctx, types.StringType, domain_names)
+	diags.Append(list_diags...)
+	m.CheckoutIds, list_diags = types.ListValueFrom(ctx, types.Int64Type, checkout_ids)
+	diags.Append(list_diags...)
+	return diags
+}
+
+// joinInt64s renders a list of IDs for error messages.
+func joinInt64s(ids []int64) string {
+	formatted := make([]string, 0, len(ids))
+	for _, id := range ids {
+		formatted = append(formatted, strconv.FormatInt(id, 10))
+	}
+	return strings.Join(formatted, ", ")
+}
@@ -0,0 +1,90 @@
+package provider
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+)
+
+func TestDomainAllocationResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain" "test" {
+  name         = "allocation-test.com"
+  creation     = "2020-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+
+resource "ghostwriter_domain_allocation" "test" {
+  project_id       = 1
+  activity_type_id = 1
+  start_date       = "2024-01-01"
+  end_date         = "2025-01-01"
+  min_age_days     = 365
+  force_delete     = true
+  depends_on       = [ghostwriter_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "project_id", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "domain_count", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "domain_ids.#", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "domain_names.#", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "checkout_ids.#", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "release_status", "Available"),
+					resource.TestCheckResourceAttrSet("ghostwriter_domain_allocation.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_domain_allocation.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ghostwriter_domain_allocation.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				ImportStateIdFunc: func(s *terraform.State) (string, error) {
+					rs, ok := s.RootModule().Resources["ghostwriter_domain_allocation.test"]
+					if !ok {
+						return "", fmt.Errorf("ghostwriter_domain_allocation.test not found in state")
+					}
+					return rs.Primary.Attributes["checkout_ids.0"], nil
+				},
+				ImportStateVerifyIgnore: []string{"force_delete", "last_updated", "min_age_days"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain" "test" {
+  name         = "allocation-test.com"
+  creation     = "2020-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+
+resource "ghostwriter_domain_allocation" "test" {
+  project_id       = 1
+  activity_type_id = 1
+  start_date       = "2024-01-01"
+  end_date         = "2025-01-01"
+  min_age_days     = 365
+  note             = "test note"
+  force_delete     = true
+  depends_on       = [ghostwriter_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "note", "test note"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_allocation.test", "domain_ids.#", "1"),
+					resource.TestCheckResourceAttrSet("ghostwriter_domain_allocation.test", "last_updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
@@ -7,23 +7,31 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &domainCheckoutResource{}
-	_ resource.ResourceWithConfigure   = &domainCheckoutResource{}
-	_ resource.ResourceWithImportState = &domainCheckoutResource{}
+	_ resource.Resource                   = &domainCheckoutResource{}
+	_ resource.ResourceWithConfigure      = &domainCheckoutResource{}
+	_ resource.ResourceWithModifyPlan     = &domainCheckoutResource{}
+	_ resource.ResourceWithValidateConfig = &domainCheckoutResource{}
+	_ resource.ResourceWithImportState    = &domainCheckoutResource{}
 )
 
 // NewdomainCheckoutResource is a helper function to simplify the provider implementation.
@@ -33,20 +41,26 @@ func NewdomainCheckoutResource() resource.Resource {
 
 // domainCheckoutResource is the resource implementation.
 type domainCheckoutResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type domainCheckoutResourceModel struct {
-	ID             types.Int64  `tfsdk:"id"`
-	ActivityTypeId types.Int64  `tfsdk:"activity_type_id"`
-	DomainId       types.Int64  `tfsdk:"domain_id"`
-	ProjectId      types.Int64  `tfsdk:"project_id"`
-	Note           types.String `tfsdk:"note"`
-	StartDate      types.String `tfsdk:"start_date"`
-	EndDate        types.String `tfsdk:"end_date"`
-	ForceDelete    types.Bool   `tfsdk:"force_delete"`
-	LastUpdated    types.String `tfsdk:"last_updated"`
+	ID               types.Int64    `tfsdk:"id"`
+	ActivityTypeId   types.Int64    `tfsdk:"activity_type_id"`
+	ActivityType     types.String   `tfsdk:"activity_type"`
+	DomainId         types.Int64    `tfsdk:"domain_id"`
+	ProjectId        types.Int64    `tfsdk:"project_id"`
+	Note             types.String   `tfsdk:"note"`
+	StartDate        types.String   `tfsdk:"start_date"`
+	EndDate          types.String   `tfsdk:"end_date"`
+	ForceDelete      types.Bool     `tfsdk:"force_delete"`
+	ReleaseStatus    types.String   `tfsdk:"release_status"`
+	AllowUnavailable types.Bool     `tfsdk:"allow_unavailable"`
+	OnDestroy        types.String   `tfsdk:"on_destroy"`
+	BurnExplanation  types.String   `tfsdk:"burn_explanation"`
+	LastUpdated      types.String   `tfsdk:"last_updated"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -62,12 +76,12 @@ func (r *domainCheckoutResource) Configure(_ context.Context, req resource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -77,13 +91,16 @@ func (r *domainCheckoutResource) Configure(_ context.Context, req resource.Confi
 }
 
 // Schema defines the schema for the resource.
-func (r *domainCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *domainCheckoutResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Checkout an existing domain in ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the domain checkout.",
@@ -92,10 +109,16 @@ func (r *domainCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequ
 			"project_id": schema.Int64Attribute{
 				Description: "The unique identifier of the project the domain should be checked out to.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"domain_id": schema.Int64Attribute{
 				Description: "The unique identifier of the domain to be checked out.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"start_date": schema.StringAttribute{
 				Description: "The start date of the project. Format: YYYY-MM-DD.",
@@ -103,7 +126,7 @@ func (r *domainCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 256),
 					stringvalidator.RegexMatches(
-						regexp.MustCompile(`\d{4}-\d{2}-\d{2}`),
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
 						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
 					),
 				},
@@ -114,14 +137,25 @@ func (r *domainCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 256),
 					stringvalidator.RegexMatches(
-						regexp.MustCompile(`\d{4}-\d{2}-\d{2}`),
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
 						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
 					),
 				},
 			},
 			"activity_type_id": schema.Int64Attribute{
-				Description: "The unique identifier of the activity type being performed.",
-				Required:    true,
+				Description: "The unique identifier of the activity type being performed. Exactly one of activity_type_id or activity_type must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("activity_type")),
+				},
+			},
+			"activity_type": schema.StringAttribute{
+				Description: "The name of the activity type being performed, resolved to activity_type_id at plan time. e.g. Command and Control",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"note": schema.StringAttribute{
 				Description: "Project-related notes, such as how the domain will be used/how it worked out.",
@@ -138,10 +172,260 @@ func (r *domainCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequ
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"release_status": schema.StringAttribute{
+				Description: "The name of the domain status the domain is set to when the checkout is destroyed. Default is Available.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultReleaseStatus),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens when the checkout is destroyed. release sets the domain to release_status and keeps the checkout record, burn marks the domain as burned with burn_explanation and keeps the checkout record, expire_now ends the checkout today and releases the domain, and delete deletes the checkout record and releases the domain. Defaults to delete when force_delete is true, and release otherwise.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyModes...),
+					stringvalidator.ConflictsWith(path.MatchRoot("force_delete")),
+				},
+			},
+			"burn_explanation": schema.StringAttribute{
+				Description: "Why the domain was burned. Required when on_destroy is burn.",
+				Optional:    true,
+			},
+			"allow_unavailable": schema.BoolAttribute{
+				Description: "If true, a domain that is already checked out, burned or otherwise not Available is reported as a warning at plan time instead of an error. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ValidateConfig checks the checkout dates are real dates in the right order and that burned
+// checkouts explain why.
+func (r *domainCheckoutResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config domainCheckoutResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateDateRange(config.StartDate, config.EndDate, "Checkout")...)
+	resp.Diagnostics.Append(validateOnDestroy(config.OnDestroy, config.BurnExplanation)...)
+}
+
+// ModifyPlan resolves the lookup table references configured by name and checks the checkout
+// against Ghostwriter, so problems are reported by terraform plan rather than by the apply.
+func (r *domainCheckoutResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.client.resolveReferences(ctx, &resp.Plan, activityTypeReference)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan domainCheckoutResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Only check checkouts that are being created or changed, as existing checkouts may have been
+	// valid when they were made
+	var state domainCheckoutResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if req.State.Raw.IsNull() || !plan.ProjectId.Equal(state.ProjectId) || !plan.EndDate.Equal(state.EndDate) {
+		resp.Diagnostics.Append(r.client.checkCheckoutProject(ctx, plan.ProjectId, plan.EndDate)...)
+	}
+	domain_changed := req.State.Raw.IsNull() || !plan.DomainId.Equal(state.DomainId)
+	if domain_changed || !plan.StartDate.Equal(state.StartDate) || !plan.EndDate.Equal(state.EndDate) {
+		resp.Diagnostics.Append(r.checkDomain(ctx, plan, state.ID.ValueInt64(), domain_changed)...)
+	}
+}
+
+// checkDomain reports an error when the domain to be checked out does not exist, expires before
+// the checkout ends or is unavailable. A domain is unavailable when another checkout that still
+// holds it overlaps this one or, if the checkout is moving to the domain, when its status is not
+// Available. Availability problems are reported as warnings when allow_unavailable is set.
+func (r *domainCheckoutResource) checkDomain(ctx context.Context, plan domainCheckoutResourceModel, checkout_id int64, check_status bool) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if plan.DomainId.IsUnknown() || plan.StartDate.IsUnknown() || plan.EndDate.IsUnknown() {
+		return diags
+	}
+
+	const querydomain = `query QueryDomain ($id: bigint, $checkout_id: bigint, $start_date: date, $end_date: date) {
+		domain(where: {id: {_eq: $id}}) {
+			id
+			name
+			expiration
+			burned_explanation
+			domainStatus {
+				domainStatus
+			}
+			healthStatus {
+				healthStatus
+			}
+		}
+		domainCheckout(where: {domainId: {_eq: $id}, id: {_neq: $checkout_id}, startDate: {_lte: $end_date}, endDate: {_gte: $start_date}}, order_by: {id: desc}) {
+			id
+			projectId
+			startDate
+			endDate
+		}
+		latest: domainCheckout(where: {domainId: {_eq: $id}}, order_by: {id: desc}, limit: 1) {
+			id
+		}
+	}`
+	request := graphql.NewRequest(querydomain)
+	request.Var("id", plan.DomainId.ValueInt64())
+	request.Var("checkout_id", checkout_id)
+	request.Var("start_date", plan.StartDate.ValueString())
+	request.Var("end_date", plan.EndDate.ValueString())
+	var respData struct {
+		Domain []struct {
+			ghostwriterDomain
+			DomainStatus *struct {
+				DomainStatus *string `json:"domainStatus"`
+			} `json:"domainStatus"`
+			HealthStatus *struct {
+				HealthStatus *string `json:"healthStatus"`
+			} `json:"healthStatus"`
+		} `json:"domain"`
+		DomainCheckout []ghostwriterDomainCheckout `json:"domainCheckout"`
+		Latest         []ghostwriterDomainCheckout `json:"latest"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		diags.AddError(
+			"Error Reading Ghostwriter Domain",
+			"Could not read Ghostwriter domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return diags
+	}
+	if len(respData.Domain) != 1 {
+		diags.AddAttributeError(
+			path.Root("domain_id"),
+			"Ghostwriter Domain Not Found",
+			"Could not find Ghostwriter domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10)+".",
+		)
+		return diags
+	}
+
+	domain := respData.Domain[0]
+	domain_name := stringValueOrEmpty(domain.Name).ValueString()
+	if domain.Expiration != nil {
+		expiration, err := time.Parse(time.DateOnly, *domain.Expiration)
+		if end, end_err := time.Parse(time.DateOnly, plan.EndDate.ValueString()); err == nil && end_err == nil && expiration.Before(end) {
+			diags.AddAttributeError(
+				path.Root("end_date"),
+				"Domain Expires Before Checkout Ends",
+				"The Ghostwriter domain "+domain_name+" expires on "+*domain.Expiration+", before the end_date "+plan.EndDate.String()+".",
+			)
+		}
+	}
+
+	unavailable := func(detail string) {
+		detail += " Choose another domain, or set allow_unavailable to true to check it out anyway."
+		if plan.AllowUnavailable.ValueBool() {
+			diags.AddAttributeWarning(path.Root("domain_id"), "Ghostwriter Domain Unavailable", detail)
+		} else {
+			diags.AddAttributeError(path.Root("domain_id"), "Ghostwriter Domain Unavailable", detail)
+		}
+	}
+	domain_status := ""
+	if domain.DomainStatus != nil {
+		domain_status = stringValueOrEmpty(domain.DomainStatus.DomainStatus).ValueString()
+	}
+	var latest_id int64
+	if len(respData.Latest) == 1 {
+		latest_id = respData.Latest[0].ID
+	}
+	overlapping := []ghostwriterDomainCheckout{}
+	for _, checkout := range respData.DomainCheckout {
+		if checkoutHoldsDomain(checkout.ID, latest_id, domain_status) {
+			overlapping = append(overlapping, checkout)
+		}
+	}
+	if len(overlapping) > 0 {
+		project_ids := []int64{}
+		for _, checkout := range overlapping {
+			project_ids = append(project_ids, int64ValueOrZero(checkout.ProjectID).ValueInt64())
+		}
+		project_names := r.projectNames(ctx, project_ids)
+		for _, checkout := range overlapping {
+			project_id := int64ValueOrZero(checkout.ProjectID).ValueInt64()
+			unavailable("The Ghostwriter domain " + domain_name + " is checked out to project " + project_names[project_id] +
+				" from " + stringValueOrEmpty(checkout.StartDate).ValueString() + " to " + stringValueOrEmpty(checkout.EndDate).ValueString() +
+				" by checkout ID " + strconv.FormatInt(checkout.ID, 10) + ".")
+		}
+	}
+	if !check_status {
+		return diags
+	}
+	if domain.HealthStatus != nil && domain.HealthStatus.HealthStatus != nil && *domain.HealthStatus.HealthStatus == "Burned" {
+		unavailable("The Ghostwriter domain " + domain_name + " is burned: " + stringValueOrEmpty(domain.BurnedExplanation).ValueString())
+	} else if domain.DomainStatus != nil && domain.DomainStatus.DomainStatus != nil && *domain.DomainStatus.DomainStatus != defaultReleaseStatus {
+		detail := "The Ghostwriter domain " + domain_name + " has the status " + *domain.DomainStatus.DomainStatus + "."
+		if domain.BurnedExplanation != nil && *domain.BurnedExplanation != "" {
+			detail += " " + *domain.BurnedExplanation
+		}
+		unavailable(detail)
+	}
+	return diags
+}
+
+// checkoutHoldsDomain reports whether a domain checkout still holds its domain. Released
+// checkouts keep their dates, so a checkout only holds the domain while it is the domain's latest
+// checkout and the domain is still Unavailable.
+func checkoutHoldsDomain(checkout_id int64, latest_checkout_id int64, domain_status string) bool {
+	return checkout_id == latest_checkout_id && domain_status == checkedOutStatus
+}
+
+// projectNames returns the codenames of Ghostwriter projects for diagnostics. Projects that cannot
+// be read are named by their ID.
+func (r *domainCheckoutResource) projectNames(ctx context.Context, project_ids []int64) map[int64]string {
+	project_names := map[int64]string{}
+	for _, project_id := range project_ids {
+		project_names[project_id] = "ID " + strconv.FormatInt(project_id, 10)
+	}
+
+	const queryprojects = `query QueryProjects ($ids: [bigint!]) {
+		project(where: {id: {_in: $ids}}) {
+			id
+			codename
+		}
+	}`
+	request := graphql.NewRequest(queryprojects)
+	request.Var("ids", project_ids)
+	var respData struct {
+		Project []ghostwriterProject `json:"project"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		tflog.Warn(ctx, fmt.Sprintf("Could not read Ghostwriter projects %v: %s", project_ids, err))
+		return project_names
+	}
+	for _, project := range respData.Project {
+		if project.Codename != nil {
+			project_names[project.ID] = *project.Codename + " (ID " + strconv.FormatInt(project.ID, 10) + ")"
+		}
+	}
+	return project_names
+}
+
 // ImportState imports the resource state from Terraform state.
 func (r *domainCheckoutResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
@@ -157,6 +441,8 @@ func (r *domainCheckoutResource) ImportState(ctx context.Context, req resource.I
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	force_delete := types.BoolValue(false)
 	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("force_delete"), &force_delete)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("release_status"), defaultReleaseStatus)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("allow_unavailable"), false)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -168,6 +454,32 @@ func (r *domainCheckoutResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	// Record the checkouts that already match, so the one created below can be told apart from
+	// older checkouts with the same attributes
+	match := newCheckoutMatch("domainCheckout", map[string]any{
+		"domainId":       plan.DomainId.ValueInt64(),
+		"projectId":      plan.ProjectId.ValueInt64(),
+		"activityTypeId": plan.ActivityTypeId.ValueInt64(),
+		"startDate":      plan.StartDate.ValueString(),
+		"endDate":        plan.EndDate.ValueString(),
+		"note":           plan.Note.ValueString(),
+	})
+	if err := match.recordExisting(ctx, r.client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain Checkouts",
+			"Could not read the existing checkouts of domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
 	// Generate API request body from plan
 	const checkoutdomain = `mutation checkoutDomain ($activity_type_id: Int!, $domain_id: Int!, $project_id: Int!, $note: String, $start_date: date!, $end_date: date!) {
 		checkoutDomain(activityTypeId: $activity_type_id, domainId: $domain_id, projectId: $project_id, note: $note, startDate: $start_date, endDate: $end_date) {
@@ -182,7 +494,9 @@ func (r *domainCheckoutResource) Create(ctx context.Context, req resource.Create
 	request.Var("note", plan.Note.ValueString())
 	request.Var("start_date", plan.StartDate.ValueString())
 	request.Var("end_date", plan.EndDate.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		CheckoutDomain checkoutResponse `json:"checkoutDomain"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error checking out domain",
@@ -191,46 +505,60 @@ func (r *domainCheckoutResource) Create(ctx context.Context, req resource.Create
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+
+	// Older Ghostwriter instances do not return the checkout, so the new matching one is used
+	checkout_id, ok := respData.CheckoutDomain.checkoutID()
+	if !ok {
+		var err error
+		checkout_id, err = match.createdID(ctx, r.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Ghostwriter Domain Checkouts",
+				"The domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10)+" was checked out, but the checkout could not be identified: "+err.Error()+". "+
+					"Import the checkout that was created with terraform import.",
+			)
+			return
+		}
+	}
 
-	// Run another query to get the ID of the domain checkout
 	const querydomaincheckout = `query QueryDomainCheckout ($id: bigint){
-		domainCheckout(where: {domain: {id: {_eq: $id}}}, order_by: {id: desc}) {
+		domainCheckout(where: {id: {_eq: $id}}) {
 			id
 			domainId
 			endDate
 			note
 			projectId
 			startDate
-			activityType {
-			  id
-			}
+			activityTypeId
 		}
 	}`
-	tflog.Debug(ctx, fmt.Sprintf("Query domain checkout ID: %v", plan.DomainId.ValueInt64()))
+	tflog.Debug(ctx, fmt.Sprintf("Query domain checkout ID: %v", checkout_id))
 	getid_request := graphql.NewRequest(querydomaincheckout)
-	getid_request.Var("id", plan.DomainId.ValueInt64())
-	var getidResp map[string]interface{}
+	getid_request.Var("id", checkout_id)
+	var getidResp struct {
+		DomainCheckout []ghostwriterDomainCheckout `json:"domainCheckout"`
+	}
 	if err := r.client.Run(ctx, getid_request, &getidResp); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter Domain Checkouts",
-			"Could not read Ghostwriter domain checkout ID "+strconv.FormatInt(plan.ID.ValueInt64(), 10)+": "+err.Error(),
+			"The domain was checked out but the checkout could not be read back: "+err.Error(),
 		)
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Domain checkout response: %v", respData))
-
-	domain_checkouts := getidResp["domainCheckout"].([]interface{})
-	if len(domain_checkouts) >= 1 {
-		latest_checkout := domain_checkouts[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(latest_checkout["id"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(latest_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		plan.DomainId = types.Int64Value(int64(latest_checkout["domainId"].(float64)))
-		plan.ProjectId = types.Int64Value(int64(latest_checkout["projectId"].(float64)))
-		plan.Note = types.StringValue(latest_checkout["note"].(string))
-		plan.StartDate = types.StringValue(latest_checkout["startDate"].(string))
-		plan.EndDate = types.StringValue(latest_checkout["endDate"].(string))
+	tflog.Debug(ctx, fmt.Sprintf("Domain checkout response: %s", responseString(getidResp)))
+
+	domain_checkouts := getidResp.DomainCheckout
+	if len(domain_checkouts) == 1 {
+		latest_checkout := domain_checkouts[0]
+		plan.ID = types.Int64Value(latest_checkout.ID)
+		plan.ActivityTypeId = int64ValueOrZero(latest_checkout.ActivityTypeID)
+		plan.DomainId = int64ValueOrZero(latest_checkout.DomainID)
+		plan.ProjectId = int64ValueOrZero(latest_checkout.ProjectID)
+		plan.Note = stringValueOrEmpty(latest_checkout.Note)
+		plan.StartDate = stringValueOrEmpty(latest_checkout.StartDate)
+		plan.EndDate = stringValueOrEmpty(latest_checkout.EndDate)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -238,13 +566,14 @@ func (r *domainCheckoutResource) Create(ctx context.Context, req resource.Create
 	} else {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter Domain Checkouts",
-			"Could not obtain any checkouts for the domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10),
+			"The domain ID "+strconv.FormatInt(plan.DomainId.ValueInt64(), 10)+" was checked out, but checkout ID "+strconv.FormatInt(checkout_id, 10)+" could not be found.",
 		)
 	}
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_domain_checkout", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -257,6 +586,14 @@ func (r *domainCheckoutResource) Read(ctx context.Context, req resource.ReadRequ
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const querydomaincheckout = `query QueryDomainCheckout ($id: bigint){
 		domainCheckout(where: {id: {_eq: $id}}) {
@@ -266,38 +603,40 @@ func (r *domainCheckoutResource) Read(ctx context.Context, req resource.ReadRequ
 			note
 			projectId
 			startDate
-			activityType {
-			  id
-			}
+			activityTypeId
 		}
 	}`
 	tflog.Debug(ctx, fmt.Sprintf("Query domain checkout ID: %v", state.ID.ValueInt64()))
 	request := graphql.NewRequest(querydomaincheckout)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		DomainCheckout []ghostwriterDomainCheckout `json:"domainCheckout"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter domain checkout ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"domainCheckout": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain Checkout",
+			"Could not read Ghostwriter domain checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Domain checkout response: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Domain checkout response: %s", responseString(respData)))
 
 	// Overwrite items with refreshed state
-	domain_checkouts := respData["domainCheckout"].([]interface{})
+	domain_checkouts := respData.DomainCheckout
 	if len(domain_checkouts) >= 1 {
-		latest_checkout := domain_checkouts[0].(map[string]interface{})
-		state.ActivityTypeId = types.Int64Value(int64(latest_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		state.DomainId = types.Int64Value(int64(latest_checkout["domainId"].(float64)))
-		state.ProjectId = types.Int64Value(int64(latest_checkout["projectId"].(float64)))
-		state.Note = types.StringValue(latest_checkout["note"].(string))
-		state.StartDate = types.StringValue(latest_checkout["startDate"].(string))
-		state.EndDate = types.StringValue(latest_checkout["endDate"].(string))
+		latest_checkout := domain_checkouts[0]
+		state.ActivityTypeId = int64ValueOrZero(latest_checkout.ActivityTypeID)
+		state.DomainId = int64ValueOrZero(latest_checkout.DomainID)
+		state.ProjectId = int64ValueOrZero(latest_checkout.ProjectID)
+		state.Note = stringValueOrEmpty(latest_checkout.Note)
+		state.StartDate = stringValueOrEmpty(latest_checkout.StartDate)
+		state.EndDate = stringValueOrEmpty(latest_checkout.EndDate)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter domain checkout ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -321,6 +660,14 @@ func (r *domainCheckoutResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updatedomaincheckout = `mutation UpdateDomainCheckout ($id: bigint, $activity_type_id: bigint, $domain_id: bigint, $project_id: bigint, $note: String, $start_date: date!, $end_date: date!) {
 		update_domainCheckout(where: {id: {_eq: $id}}, _set: {activityTypeId: $activity_type_id, domainId: $domain_id, endDate: $end_date, note: $note, projectId: $project_id, startDate: $start_date}) {
@@ -331,9 +678,7 @@ func (r *domainCheckoutResource) Update(ctx context.Context, req resource.Update
 				note
 				projectId
 				startDate
-				activityType {
-				  id
-				}
+				activityTypeId
 			}
 		}
 	}`
@@ -346,7 +691,9 @@ func (r *domainCheckoutResource) Update(ctx context.Context, req resource.Update
 	request.Var("note", plan.Note.ValueString())
 	request.Var("start_date", plan.StartDate.ValueString())
 	request.Var("end_date", plan.EndDate.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateDomainCheckout mutationResponse[ghostwriterDomainCheckout] `json:"update_domainCheckout"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Domain Checkout",
@@ -355,18 +702,18 @@ func (r *domainCheckoutResource) Update(ctx context.Context, req resource.Update
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
 
-	updated_domain_checkouts := respData["update_domainCheckout"].(map[string]interface{})["returning"].([]interface{})
+	updated_domain_checkouts := respData.UpdateDomainCheckout.Returning
 	if len(updated_domain_checkouts) == 1 {
-		updated_domain_checkout := updated_domain_checkouts[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(updated_domain_checkout["id"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(updated_domain_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		plan.DomainId = types.Int64Value(int64(updated_domain_checkout["domainId"].(float64)))
-		plan.ProjectId = types.Int64Value(int64(updated_domain_checkout["projectId"].(float64)))
-		plan.Note = types.StringValue(updated_domain_checkout["note"].(string))
-		plan.StartDate = types.StringValue(updated_domain_checkout["startDate"].(string))
-		plan.EndDate = types.StringValue(updated_domain_checkout["endDate"].(string))
+		updated_domain_checkout := updated_domain_checkouts[0]
+		plan.ID = types.Int64Value(updated_domain_checkout.ID)
+		plan.ActivityTypeId = int64ValueOrZero(updated_domain_checkout.ActivityTypeID)
+		plan.DomainId = int64ValueOrZero(updated_domain_checkout.DomainID)
+		plan.ProjectId = int64ValueOrZero(updated_domain_checkout.ProjectID)
+		plan.Note = stringValueOrEmpty(updated_domain_checkout.Note)
+		plan.StartDate = stringValueOrEmpty(updated_domain_checkout.StartDate)
+		plan.EndDate = stringValueOrEmpty(updated_domain_checkout.EndDate)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -382,6 +729,7 @@ func (r *domainCheckoutResource) Update(ctx context.Context, req resource.Update
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_domain_checkout", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -394,10 +742,21 @@ func (r *domainCheckoutResource) Delete(ctx context.Context, req resource.Delete
 		return
 	}
 
-	if state.ForceDelete.ValueBool() {
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	on_destroy := onDestroyMode(state.OnDestroy, state.ForceDelete)
+	switch on_destroy {
+	case onDestroyDelete:
 		// Generate API request body from plan
 		const deletedomaincheckout = `mutation DeleteDomainCheckout ($id: bigint) {
 			delete_domainCheckout(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -406,30 +765,88 @@ func (r *domainCheckoutResource) Delete(ctx context.Context, req resource.Delete
 		tflog.Debug(ctx, fmt.Sprintf("Deleting domain checkout: %v", state))
 		request := graphql.NewRequest(deletedomaincheckout)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Domain Checkout", "domain checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-	} else {
-		tflog.Info(ctx, "Cowardly refusing to delete domain checkout. Releasing domain to the ghostwriter pool and the domain checkout record will remain. Set force_delete to true to delete domain checkout record.")
+	case onDestroyExpireNow:
+		const expiredomaincheckout = `mutation ExpireDomainCheckout ($id: bigint, $end_date: date) {
+			update_domainCheckout(where: {id: {_eq: $id}}, _set: {endDate: $end_date}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		tflog.Debug(ctx, fmt.Sprintf("Expiring domain checkout: %v", state))
+		request := graphql.NewRequest(expiredomaincheckout)
+		request.Var("id", state.ID.ValueInt64())
+		request.Var("end_date", checkoutExpiryDate(state.StartDate))
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "expire", "Error Expiring Ghostwriter Domain Checkout", "domain checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	case onDestroyBurn:
+		// Burned domains are not returned to the pool, and the checkout record is kept as history
+		const burndomain = `mutation BurnDomain ($id: bigint, $status_id: bigint, $burned_explanation: String) {
+			update_domain(where: {id: {_eq: $id}}, _set: {domainStatusId: $status_id, burned_explanation: $burned_explanation}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		tflog.Debug(ctx, fmt.Sprintf("Burning domain: %v", state))
+		status_id, err := r.client.lookupID(ctx, "domainStatus", "domainStatus", burnedStatus)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Burning Ghostwriter Domain",
+				"Could not resolve the domain status "+burnedStatus+": "+err.Error(),
+			)
+			return
+		}
+		request := graphql.NewRequest(burndomain)
+		request.Var("id", state.DomainId.ValueInt64())
+		request.Var("status_id", status_id)
+		request.Var("burned_explanation", state.BurnExplanation.ValueString())
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "burn", "Error Burning Ghostwriter Domain", "domain ID "+strconv.FormatInt(state.DomainId.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		resp.Diagnostics.Append(r.client.audit(ctx, onDestroyAuditActions[on_destroy], "ghostwriter_domain_checkout", req.State.Raw, tftypes.Value{})...)
+		return
+	default:
+		tflog.Info(ctx, "Cowardly refusing to delete domain checkout. Releasing domain to the ghostwriter pool and the domain checkout record will remain. Set on_destroy to delete to delete domain checkout record.")
 	}
 	// Generate API request body from plan
-	const releasedomain = `mutation UpdateDomain ($id: bigint) {
-		update_domain(where: {id: {_eq: $id}}, _set: {domainStatusId: 1}) {
+	const releasedomain = `mutation UpdateDomain ($id: bigint, $status_id: bigint) {
+		update_domain(where: {id: {_eq: $id}}, _set: {domainStatusId: $status_id}) {
+			affected_rows
 			returning {
 				id
 			}
 		}
 	}`
 	tflog.Debug(ctx, fmt.Sprintf("Releasing domain to the pool: %v", state))
+	release_status := state.ReleaseStatus.ValueString()
+	if release_status == "" {
+		release_status = defaultReleaseStatus
+	}
+	status_id, err := r.client.lookupID(ctx, "domainStatus", "domainStatus", release_status)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("release_status"),
+			"Error Releasing Ghostwriter Domain",
+			"Could not resolve the domain status "+release_status+": "+err.Error(),
+		)
+		return
+	}
 	request := graphql.NewRequest(releasedomain)
 	request.Var("id", state.DomainId.ValueInt64())
-	var respData map[string]interface{}
-	if err := r.client.Run(ctx, request, &respData); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Ghostwriter Domain Checkout",
-			"Could not delete domain checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
-		)
+	request.Var("status_id", status_id)
+	resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "release", "Error Releasing Ghostwriter Domain", "domain ID "+strconv.FormatInt(state.DomainId.ValueInt64(), 10))...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, onDestroyAuditActions[on_destroy], "ghostwriter_domain_checkout", req.State.Raw, tftypes.Value{})...)
 }
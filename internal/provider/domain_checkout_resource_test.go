@@ -1,9 +1,16 @@
 package provider
 
 import (
+	"fmt"
+	"regexp"
 	"testing"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/terraform"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
 )
 
 func TestDomainCheckoutResource(t *testing.T) {
@@ -30,6 +37,8 @@ resource "ghostwriter_domain_checkout" "test" {
 					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "activity_type_id", "1"),
 					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "note", ""),
 					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "force_delete", "true"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "release_status", "Available"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "allow_unavailable", "false"),
 					resource.TestCheckResourceAttrSet("ghostwriter_domain_checkout.test", "id"),
 					resource.TestCheckResourceAttrSet("ghostwriter_domain_checkout.test", "last_updated"),
 				),
@@ -56,6 +65,12 @@ resource "ghostwriter_domain_checkout" "test" {
   force_delete = true
 }
 `,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ghostwriter_domain_checkout.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("ghostwriter_domain_checkout.test", tfjsonpath.New("id"), knownvalue.NotNull()),
+					},
+				},
 				Check: resource.ComposeAggregateTestCheckFunc(
 					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "project_id", "1"),
 					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.test", "domain_id", "1"),
@@ -72,3 +87,228 @@ resource "ghostwriter_domain_checkout" "test" {
 		},
 	})
 }
+
+func TestDomainCheckoutResourceInvalidDates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain_checkout" "test" {
+  project_id       = 1
+  domain_id        = 1
+  start_date       = "2025-01-01"
+  end_date         = "2024-01-01"
+  activity_type_id = 1
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Checkout Dates"),
+			},
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain_checkout" "test" {
+  project_id       = 1
+  domain_id        = 1
+  start_date       = "2024-02-30"
+  end_date         = "2025-01-01"
+  activity_type_id = 1
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Checkout Date"),
+			},
+		},
+	})
+}
+
+func TestDomainCheckoutResourceOnDestroy(t *testing.T) {
+	domains := `
+resource "ghostwriter_domain" "burn" {
+  name         = "on-destroy-burn.com"
+  creation     = "2020-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+
+resource "ghostwriter_domain" "expire" {
+  name         = "on-destroy-expire.com"
+  creation     = "2020-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+
+resource "ghostwriter_domain" "delete" {
+  name         = "on-destroy-delete.com"
+  creation     = "2020-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+`
+	// The expired checkout is kept by Ghostwriter, so it is imported to check its end date
+	var expired_id string
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain_checkout" "test" {
+  project_id       = 1
+  domain_id        = 1
+  start_date       = "2024-01-01"
+  end_date         = "2025-01-01"
+  activity_type_id = 1
+  on_destroy       = "burn"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing Burn Explanation"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + domains + `
+resource "ghostwriter_domain_checkout" "burn" {
+  project_id       = 1
+  domain_id        = ghostwriter_domain.burn.id
+  start_date       = "2024-01-01"
+  end_date         = "2098-01-01"
+  activity_type_id = 1
+  on_destroy       = "burn"
+  burn_explanation = "Flagged by the blue team"
+}
+
+resource "ghostwriter_domain_checkout" "expire" {
+  project_id       = 1
+  domain_id        = ghostwriter_domain.expire.id
+  start_date       = "2024-01-01"
+  end_date         = "2098-01-01"
+  activity_type_id = 1
+  on_destroy       = "expire_now"
+}
+
+resource "ghostwriter_domain_checkout" "delete" {
+  project_id       = 1
+  domain_id        = ghostwriter_domain.delete.id
+  start_date       = "2024-01-01"
+  end_date         = "2098-01-01"
+  activity_type_id = 1
+  on_destroy       = "delete"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.burn", "on_destroy", "burn"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.expire", "on_destroy", "expire_now"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.delete", "on_destroy", "delete"),
+					resource.TestCheckResourceAttr("ghostwriter_domain_checkout.delete", "force_delete", "false"),
+					resource.TestCheckResourceAttrWith("ghostwriter_domain_checkout.expire", "id", func(value string) error {
+						expired_id = value
+						return nil
+					}),
+				),
+			},
+			// Destroy the checkouts. Burning the domain changes its burned_explanation, so the
+			// domain resource plans to reset it afterwards.
+			{
+				Config:             providerConfig + domains,
+				ExpectNonEmptyPlan: true,
+			},
+			// Read the domains the checkouts left behind
+			{
+				Config: providerConfig + domains + `
+data "ghostwriter_domain" "burn" {
+  id = ghostwriter_domain.burn.id
+}
+
+data "ghostwriter_domain" "expire" {
+  id                = ghostwriter_domain.expire.id
+  allow_checked_out = true
+}
+
+data "ghostwriter_domain" "delete" {
+  id = ghostwriter_domain.delete.id
+}
+`,
+				ExpectNonEmptyPlan: true,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.burn", "domain_status", "Burned"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.burn", "burned_explanation", "Flagged by the blue team"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.expire", "domain_status", "Available"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.expire", "last_project_id", "1"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.delete", "domain_status", "Available"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.delete", "last_project_id", "0"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.delete", "current_checkout_id", "0"),
+				),
+			},
+			// The expired checkout ends today
+			{
+				Config: providerConfig + domains + `
+resource "ghostwriter_domain_checkout" "expire" {
+  project_id       = 1
+  domain_id        = ghostwriter_domain.expire.id
+  start_date       = "2024-01-01"
+  end_date         = "2098-01-01"
+  activity_type_id = 1
+}
+`,
+				ResourceName: "ghostwriter_domain_checkout.expire",
+				ImportState:  true,
+				ImportStateIdFunc: func(_ *terraform.State) (string, error) {
+					return expired_id, nil
+				},
+				ImportStateCheck: func(states []*terraform.InstanceState) error {
+					if len(states) != 1 {
+						return fmt.Errorf("expected 1 imported checkout, got %d", len(states))
+					}
+					today := time.Now().Format(time.DateOnly)
+					if end_date := states[0].Attributes["end_date"]; end_date != today {
+						return fmt.Errorf("expected the expired checkout to end on %s, got %s", today, end_date)
+					}
+					return nil
+				},
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestCheckoutHoldsDomain(t *testing.T) {
+	tests := []struct {
+		name            string
+		checkoutID      int64
+		latestID        int64
+		domainStatus    string
+		wantHoldsDomain bool
+	}{
+		{
+			name:            "latest checkout of a checked out domain",
+			checkoutID:      7,
+			latestID:        7,
+			domainStatus:    "Unavailable",
+			wantHoldsDomain: true,
+		},
+		{
+			name:         "released checkout",
+			checkoutID:   7,
+			latestID:     7,
+			domainStatus: "Available",
+		},
+		{
+			name:         "burned checkout",
+			checkoutID:   7,
+			latestID:     7,
+			domainStatus: "Burned",
+		},
+		{
+			name:         "checkout released before a later one",
+			checkoutID:   5,
+			latestID:     7,
+			domainStatus: "Unavailable",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if holds := checkoutHoldsDomain(test.checkoutID, test.latestID, test.domainStatus); holds != test.wantHoldsDomain {
+				t.Errorf("expected %t, got %t", test.wantHoldsDomain, holds)
+			}
+		})
+	}
+}
@@ -0,0 +1,297 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &domainDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainDataSource{}
+)
+
+// NewdomainDataSource is a helper function to simplify the provider implementation.
+func NewdomainDataSource() datasource.DataSource {
+	return &domainDataSource{}
+}
+
+// domainDataSource is the data source implementation.
+type domainDataSource struct {
+	client *ghostwriterClient
+}
+
+// domainDataSourceModel maps the domain schema data.
+type domainDataSourceModel struct {
+	ID                types.Int64  `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	AllowCheckedOut   types.Bool   `tfsdk:"allow_checked_out"`
+	Registrar         types.String `tfsdk:"registrar"`
+	Creation          types.String `tfsdk:"creation"`
+	Expiration        types.String `tfsdk:"expiration"`
+	AutoRenew         types.Bool   `tfsdk:"auto_renew"`
+	BurnedExplanation types.String `tfsdk:"burned_explanation"`
+	Note              types.String `tfsdk:"note"`
+	VtPermalink       types.String `tfsdk:"vt_permalink"`
+	DomainStatus      types.String `tfsdk:"domain_status"`
+	HealthStatus      types.String `tfsdk:"health_status"`
+	WhoisStatus       types.String `tfsdk:"whois_status"`
+	LastProjectId     types.Int64  `tfsdk:"last_project_id"`
+	CurrentCheckoutId types.Int64  `tfsdk:"current_checkout_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *domainDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domain"
+}
+
+// Configure adds the provider configured client to the datasource.
+func (d *domainDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Schema defines the schema for the data source.
+func (d *domainDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Search an existing domain in ghostwriter by name or ID.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the domain. Exactly one of id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("name")),
+				},
+			},
+			"name": schema.StringAttribute{
+				Description: "The domain name. e.g. example.com. Exactly one of id or name must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"allow_checked_out": schema.BoolAttribute{
+				Description: "If false, reading a domain that is currently checked out to a project is an error. Set to true when the domain is checked out by the same configuration. Default is false.",
+				Optional:    true,
+			},
+			"registrar": schema.StringAttribute{
+				Description: "The domain registrar.",
+				Computed:    true,
+			},
+			"creation": schema.StringAttribute{
+				Description: "The domain creation date.",
+				Computed:    true,
+			},
+			"expiration": schema.StringAttribute{
+				Description: "The domain expiration date.",
+				Computed:    true,
+			},
+			"auto_renew": schema.BoolAttribute{
+				Description: "Whether the domain is set to auto-renew.",
+				Computed:    true,
+			},
+			"burned_explanation": schema.StringAttribute{
+				Description: "Explanation of why the domain was burned.",
+				Computed:    true,
+			},
+			"note": schema.StringAttribute{
+				Description: "Additional notes about the domain.",
+				Computed:    true,
+			},
+			"vt_permalink": schema.StringAttribute{
+				Description: "The VirusTotal permalink for the domain.",
+				Computed:    true,
+			},
+			"domain_status": schema.StringAttribute{
+				Description: "The name of the domain status. e.g. Available",
+				Computed:    true,
+			},
+			"health_status": schema.StringAttribute{
+				Description: "The name of the health status. e.g. Healthy",
+				Computed:    true,
+			},
+			"whois_status": schema.StringAttribute{
+				Description: "The name of the WHOIS status. e.g. Enabled",
+				Computed:    true,
+			},
+			"last_project_id": schema.Int64Attribute{
+				Description: "The ID of the project the domain was most recently checked out to, or 0 if it has never been checked out.",
+				Computed:    true,
+			},
+			"current_checkout_id": schema.Int64Attribute{
+				Description: "The ID of the checkout the domain is currently used by, or 0 if it is not checked out or the checkout was released.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *domainDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state domainDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	where := map[string]any{}
+	target := "domain " + state.Name.ValueString()
+	if !state.ID.IsNull() {
+		where["id"] = map[string]any{"_eq": state.ID.ValueInt64()}
+		target = "domain ID " + strconv.FormatInt(state.ID.ValueInt64(), 10)
+	} else {
+		where["name"] = map[string]any{"_eq": state.Name.ValueString()}
+	}
+
+	// The latest checkouts by date and by ID are read alongside the domain
+	const querydomain = `query QueryDomain ($where: domain_bool_exp!, $checkout_where: domainCheckout_bool_exp!) {
+		domain(where: $where) {
+			id
+			burned_explanation
+			autoRenew
+			name
+			registrar
+			creation
+			expiration
+			note
+			vtPermalink
+			domainStatus {
+				domainStatus
+			}
+			healthStatus {
+				healthStatus
+			}
+			whoisStatus {
+				whoisStatus
+			}
+		}
+		latest: domainCheckout(where: $checkout_where, order_by: [{startDate: desc}, {id: desc}], limit: 1) {
+			id
+			projectId
+		}
+		current: domainCheckout(where: $checkout_where, order_by: {id: desc}, limit: 1) {
+			id
+			projectId
+			startDate
+			endDate
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Reading %s", target))
+	request := graphql.NewRequest(querydomain)
+	request.Var("where", where)
+	request.Var("checkout_where", map[string]any{"domain": where})
+	var respData struct {
+		Domain []struct {
+			ghostwriterDomain
+			DomainStatus *struct {
+				DomainStatus *string `json:"domainStatus"`
+			} `json:"domainStatus"`
+			HealthStatus *struct {
+				HealthStatus *string `json:"healthStatus"`
+			} `json:"healthStatus"`
+			WhoisStatus *struct {
+				WhoisStatus *string `json:"whoisStatus"`
+			} `json:"whoisStatus"`
+		} `json:"domain"`
+		Latest  []ghostwriterDomainCheckout `json:"latest"`
+		Current []ghostwriterDomainCheckout `json:"current"`
+	}
+	if err := d.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain",
+			"Could not read Ghostwriter "+target+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	if len(respData.Domain) != 1 {
+		resp.Diagnostics.AddError(
+			"Ghostwriter Domain Not Found",
+			"Could not find Ghostwriter "+target+". Check the domain is registered in Ghostwriter.",
+		)
+		return
+	}
+
+	domain := respData.Domain[0]
+	state.ID = types.Int64Value(domain.ID)
+	state.Name = stringValueOrEmpty(domain.Name)
+	state.Registrar = stringValueOrEmpty(domain.Registrar)
+	state.Creation = stringValueOrEmpty(domain.Creation)
+	state.Expiration = stringValueOrEmpty(domain.Expiration)
+	state.AutoRenew = boolValueOrFalse(domain.AutoRenew)
+	state.BurnedExplanation = stringValueOrEmpty(domain.BurnedExplanation)
+	state.Note = stringValueOrEmpty(domain.Note)
+	state.VtPermalink = stringValueOrEmpty(domain.VtPermalink)
+	state.DomainStatus = types.StringValue("")
+	if domain.DomainStatus != nil {
+		state.DomainStatus = stringValueOrEmpty(domain.DomainStatus.DomainStatus)
+	}
+	state.HealthStatus = types.StringValue("")
+	if domain.HealthStatus != nil {
+		state.HealthStatus = stringValueOrEmpty(domain.HealthStatus.HealthStatus)
+	}
+	state.WhoisStatus = types.StringValue("")
+	if domain.WhoisStatus != nil {
+		state.WhoisStatus = stringValueOrEmpty(domain.WhoisStatus.WhoisStatus)
+	}
+	state.LastProjectId = types.Int64Value(0)
+	if len(respData.Latest) == 1 {
+		state.LastProjectId = int64ValueOrZero(respData.Latest[0].ProjectID)
+	}
+	state.CurrentCheckoutId = types.Int64Value(0)
+	// Released checkouts keep their dates, so the latest checkout is only current while it still
+	// holds the domain, as for the availability check of ghostwriter_domain_checkout
+	today := time.Now().Format(time.DateOnly)
+	if len(respData.Current) == 1 && checkoutHoldsDomain(respData.Current[0].ID, respData.Current[0].ID, state.DomainStatus.ValueString()) &&
+		stringValueOrEmpty(respData.Current[0].StartDate).ValueString() <= today && stringValueOrEmpty(respData.Current[0].EndDate).ValueString() >= today {
+		current := respData.Current[0]
+		state.CurrentCheckoutId = types.Int64Value(current.ID)
+		if !state.AllowCheckedOut.ValueBool() {
+			resp.Diagnostics.AddError(
+				"Ghostwriter Domain Already Checked Out",
+				"The Ghostwriter "+target+" is currently checked out to project ID "+strconv.FormatInt(int64ValueOrZero(current.ProjectID).ValueInt64(), 10)+
+					" by checkout ID "+strconv.FormatInt(current.ID, 10)+". Choose another domain, or set allow_checked_out to true.",
+			)
+			return
+		}
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,39 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDomainDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain" "test" {
+  name         = "domain-data-source.com"
+  registrar    = "Namecheap"
+  creation     = "2024-01-01"
+  expiration   = "2099-01-01"
+  force_delete = true
+}
+
+data "ghostwriter_domain" "test" {
+  name       = ghostwriter_domain.test.name
+  depends_on = [ghostwriter_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ghostwriter_domain.test", "id", "ghostwriter_domain.test", "id"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.test", "registrar", "Namecheap"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.test", "expiration", "2099-01-01"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domain.test", "current_checkout_id", "0"),
+					resource.TestCheckResourceAttrSet("data.ghostwriter_domain.test", "domain_status"),
+				),
+			},
+		},
+	})
+}
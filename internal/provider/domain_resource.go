@@ -7,14 +7,18 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
@@ -33,22 +37,23 @@ func NewdomainResource() resource.Resource {
 
 // domainResource is the resource implementation.
 type domainResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type domainResourceModel struct {
-	ID                types.Int64  `tfsdk:"id"`
-	Name              types.String `tfsdk:"name"`
-	Registrar         types.String `tfsdk:"registrar"`
-	Creation          types.String `tfsdk:"creation"`
-	Expiration        types.String `tfsdk:"expiration"`
-	AutoRenew         types.Bool   `tfsdk:"auto_renew"`
-	BurnedExplanation types.String `tfsdk:"burned_explanation"`
-	Note              types.String `tfsdk:"note"`
-	VtPermalink       types.String `tfsdk:"vt_permalink"`
-	ForceDelete       types.Bool   `tfsdk:"force_delete"`
-	LastUpdated       types.String `tfsdk:"last_updated"`
+	ID                types.Int64    `tfsdk:"id"`
+	Name              types.String   `tfsdk:"name"`
+	Registrar         types.String   `tfsdk:"registrar"`
+	Creation          types.String   `tfsdk:"creation"`
+	Expiration        types.String   `tfsdk:"expiration"`
+	AutoRenew         types.Bool     `tfsdk:"auto_renew"`
+	BurnedExplanation types.String   `tfsdk:"burned_explanation"`
+	Note              types.String   `tfsdk:"note"`
+	VtPermalink       types.String   `tfsdk:"vt_permalink"`
+	ForceDelete       types.Bool     `tfsdk:"force_delete"`
+	LastUpdated       types.String   `tfsdk:"last_updated"`
+	Timeouts          timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -64,12 +69,12 @@ func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequ
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -79,13 +84,16 @@ func (r *domainResource) Configure(_ context.Context, req resource.ConfigureRequ
 }
 
 // Schema defines the schema for the resource.
-func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *domainResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Register a domain in Ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the domain.",
@@ -177,6 +185,14 @@ func (r *domainResource) Schema(_ context.Context, _ resource.SchemaRequest, res
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -206,6 +222,14 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const insertdomain = `mutation InsertDomain ($burned_explanation: String, $autoRenew: Boolean, $name: String, $registrar: String, $creation: date, $expiration: date, $note: String, $vtPermalink: String) {
 		insert_domain(objects: {burned_explanation: $burned_explanation, autoRenew: $autoRenew, name: $name, registrar: $registrar, creation: $creation, expiration: $expiration, note: $note, vtPermalink: $vtPermalink}) {
@@ -232,7 +256,9 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 	request.Var("expiration", plan.Expiration.ValueString())
 	request.Var("note", plan.Note.ValueString())
 	request.Var("vtPermalink", plan.VtPermalink.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		InsertDomain mutationResponse[ghostwriterDomain] `json:"insert_domain"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating domain",
@@ -241,18 +267,19 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	domains := respData["insert_domain"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	domains := respData.InsertDomain.Returning
 	if len(domains) == 1 {
-		domain := domains[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(domain["id"].(float64)))
-		plan.AutoRenew = types.BoolValue(domain["autoRenew"].(bool))
-		plan.BurnedExplanation = types.StringValue(domain["burned_explanation"].(string))
-		plan.Creation = types.StringValue(domain["creation"].(string))
-		plan.Expiration = types.StringValue(domain["expiration"].(string))
-		plan.Name = types.StringValue(domain["name"].(string))
-		plan.Note = types.StringValue(domain["note"].(string))
-		plan.Registrar = types.StringValue(domain["registrar"].(string))
+		domain := domains[0]
+		plan.ID = types.Int64Value(domain.ID)
+		plan.AutoRenew = boolValueOrFalse(domain.AutoRenew)
+		plan.BurnedExplanation = stringValueOrEmpty(domain.BurnedExplanation)
+		plan.Creation = stringValueOrEmpty(domain.Creation)
+		plan.Expiration = stringValueOrEmpty(domain.Expiration)
+		plan.Name = stringValueOrEmpty(domain.Name)
+		plan.Note = stringValueOrEmpty(domain.Note)
+		plan.Registrar = stringValueOrEmpty(domain.Registrar)
+		plan.VtPermalink = stringValueOrEmpty(domain.VtPermalink)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -268,6 +295,7 @@ func (r *domainResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_domain", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -280,6 +308,14 @@ func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, res
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const querydomain = `query QueryDomain ($id: bigint){
 		domain(where: {id: {_eq: $id}}) {
@@ -297,32 +333,36 @@ func (r *domainResource) Read(ctx context.Context, req resource.ReadRequest, res
 	tflog.Debug(ctx, fmt.Sprintf("Reading domain: %v", state.ID))
 	request := graphql.NewRequest(querydomain)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		Domain []ghostwriterDomain `json:"domain"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter domain ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"domain": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain",
+			"Could not read Ghostwriter domain ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
 	// Overwrite items with refreshed state
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	domains := respData["domain"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	domains := respData.Domain
 	if len(domains) == 1 {
-		domain := domains[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(domain["id"].(float64)))
-		state.AutoRenew = types.BoolValue(domain["autoRenew"].(bool))
-		state.BurnedExplanation = types.StringValue(domain["burned_explanation"].(string))
-		state.Creation = types.StringValue(domain["creation"].(string))
-		state.Expiration = types.StringValue(domain["expiration"].(string))
-		state.Name = types.StringValue(domain["name"].(string))
-		state.Note = types.StringValue(domain["note"].(string))
-		state.Registrar = types.StringValue(domain["registrar"].(string))
-		state.VtPermalink = types.StringValue(domain["vtPermalink"].(string))
+		domain := domains[0]
+		state.ID = types.Int64Value(domain.ID)
+		state.AutoRenew = boolValueOrFalse(domain.AutoRenew)
+		state.BurnedExplanation = stringValueOrEmpty(domain.BurnedExplanation)
+		state.Creation = stringValueOrEmpty(domain.Creation)
+		state.Expiration = stringValueOrEmpty(domain.Expiration)
+		state.Name = stringValueOrEmpty(domain.Name)
+		state.Note = stringValueOrEmpty(domain.Note)
+		state.Registrar = stringValueOrEmpty(domain.Registrar)
+		state.VtPermalink = stringValueOrEmpty(domain.VtPermalink)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter domain ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -346,6 +386,14 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updatedomain = `mutation UpdateDomain ($id: bigint, $burned_explanation: String, $autoRenew: Boolean, $name: String, $registrar: String, $creation: date, $expiration: date, $note: String, $vtPermalink: String) {
 		update_domain(where: {id: {_eq: $id}}, _set: {burned_explanation: $burned_explanation, autoRenew: $autoRenew, name: $name, registrar: $registrar, creation: $creation, expiration: $expiration, note: $note, vtPermalink: $vtPermalink}) {
@@ -373,7 +421,9 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 	request.Var("expiration", plan.Expiration.ValueString())
 	request.Var("note", plan.Note.ValueString())
 	request.Var("vtPermalink", plan.VtPermalink.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateDomain mutationResponse[ghostwriterDomain] `json:"update_domain"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Domain",
@@ -382,18 +432,19 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	updated_domains := respData["update_domain"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_domains := respData.UpdateDomain.Returning
 	if len(updated_domains) == 1 {
-		domainID := updated_domains[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(domainID["id"].(float64)))
-		plan.AutoRenew = types.BoolValue(domainID["autoRenew"].(bool))
-		plan.BurnedExplanation = types.StringValue(domainID["burned_explanation"].(string))
-		plan.Creation = types.StringValue(domainID["creation"].(string))
-		plan.Expiration = types.StringValue(domainID["expiration"].(string))
-		plan.Name = types.StringValue(domainID["name"].(string))
-		plan.Note = types.StringValue(domainID["note"].(string))
-		plan.Registrar = types.StringValue(domainID["registrar"].(string))
+		domain := updated_domains[0]
+		plan.ID = types.Int64Value(domain.ID)
+		plan.AutoRenew = boolValueOrFalse(domain.AutoRenew)
+		plan.BurnedExplanation = stringValueOrEmpty(domain.BurnedExplanation)
+		plan.Creation = stringValueOrEmpty(domain.Creation)
+		plan.Expiration = stringValueOrEmpty(domain.Expiration)
+		plan.Name = stringValueOrEmpty(domain.Name)
+		plan.Note = stringValueOrEmpty(domain.Note)
+		plan.Registrar = stringValueOrEmpty(domain.Registrar)
+		plan.VtPermalink = stringValueOrEmpty(domain.VtPermalink)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -409,6 +460,7 @@ func (r *domainResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_domain", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -421,10 +473,19 @@ func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
 	if state.ForceDelete.ValueBool() {
 		// Generate API request body from plan
 		const deletedomain = `mutation DeleteDomain ($id: bigint){
 			delete_domain(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -432,12 +493,12 @@ func (r *domainResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		}`
 		request := graphql.NewRequest(deletedomain)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
-			return
-		}
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Domain", "domain ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
 	} else {
 		tflog.Info(ctx, "Cowardly refusing to delete domain. Domain expiration will be managed by ghostwriter. Set force_delete to true to delete domain.")
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_domain", req.State.Raw, tftypes.Value{})...)
 }
@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
@@ -13,9 +14,12 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
@@ -34,20 +38,21 @@ func NewdomainserverResource() resource.Resource {
 
 // domainserverResource is the resource implementation.
 type domainserverResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type domainserverResourceModel struct {
-	ID                     types.Int64  `tfsdk:"id"`
-	DomainCheckoutID       types.Int64  `tfsdk:"domain_checkout_id"`
-	ProjectID              types.Int64  `tfsdk:"project_id"`
-	StaticServerCheckoutID types.Int64  `tfsdk:"static_server_checkout_id"`
-	TransientServerID      types.Int64  `tfsdk:"cloud_server_id"`
-	Subdomain              types.String `tfsdk:"subdomain"`
-	Endpoint               types.String `tfsdk:"endpoint"`
-	ForceDelete            types.Bool   `tfsdk:"force_delete"`
-	LastUpdated            types.String `tfsdk:"last_updated"`
+	ID                     types.Int64    `tfsdk:"id"`
+	DomainCheckoutID       types.Int64    `tfsdk:"domain_checkout_id"`
+	ProjectID              types.Int64    `tfsdk:"project_id"`
+	StaticServerCheckoutID types.Int64    `tfsdk:"static_server_checkout_id"`
+	TransientServerID      types.Int64    `tfsdk:"cloud_server_id"`
+	Subdomain              types.String   `tfsdk:"subdomain"`
+	Endpoint               types.String   `tfsdk:"endpoint"`
+	ForceDelete            types.Bool     `tfsdk:"force_delete"`
+	LastUpdated            types.String   `tfsdk:"last_updated"`
+	Timeouts               timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -63,12 +68,12 @@ func (r *domainserverResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -78,13 +83,16 @@ func (r *domainserverResource) Configure(_ context.Context, req resource.Configu
 }
 
 // Schema defines the schema for the resource.
-func (r *domainserverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *domainserverResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Associate a Domain + Server in Ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the association.",
@@ -93,6 +101,9 @@ func (r *domainserverResource) Schema(_ context.Context, _ resource.SchemaReques
 			"domain_checkout_id": schema.Int64Attribute{
 				Description: "The identifier of the domain checkout resource.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"project_id": schema.Int64Attribute{
 				Description: "The unique identifier of the project the domain + server association should be created for.",
@@ -141,6 +152,14 @@ func (r *domainserverResource) Schema(_ context.Context, _ resource.SchemaReques
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -170,6 +189,14 @@ func (r *domainserverResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	var insertdomainserver string
 	if plan.StaticServerCheckoutID.ValueInt64() != 0 {
@@ -216,7 +243,9 @@ func (r *domainserverResource) Create(ctx context.Context, req resource.CreateRe
 	}
 	request.Var("subdomain", plan.Subdomain.ValueString())
 	request.Var("endpoint", plan.Endpoint.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		InsertDomainServerConnection mutationResponse[ghostwriterDomainServerConnection] `json:"insert_domainServerConnection"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating domain server association",
@@ -225,27 +254,17 @@ func (r *domainserverResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	domainservers := respData["insert_domainServerConnection"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	domainservers := respData.InsertDomainServerConnection.Returning
 	if len(domainservers) == 1 {
-		domainserver := domainservers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(domainserver["id"].(float64)))
-		plan.DomainCheckoutID = types.Int64Value(int64(domainserver["domainId"].(float64)))
-		plan.ProjectID = types.Int64Value(int64(domainserver["projectId"].(float64)))
-		static_server_id, ok := domainserver["staticServerId"].(float64)
-		if !ok || domainserver["staticServerId"] == nil {
-			plan.StaticServerCheckoutID = types.Int64Value(0)
-		} else {
-			plan.StaticServerCheckoutID = types.Int64Value(int64(static_server_id))
-		}
-		cloud_server_id, ok := domainserver["transientServerId"].(float64)
-		if !ok || domainserver["transientServerId"] == nil {
-			plan.TransientServerID = types.Int64Value(0)
-		} else {
-			plan.TransientServerID = types.Int64Value(int64(cloud_server_id))
-		}
-		plan.Subdomain = types.StringValue(domainserver["subdomain"].(string))
-		plan.Endpoint = types.StringValue(domainserver["endpoint"].(string))
+		domainserver := domainservers[0]
+		plan.ID = types.Int64Value(domainserver.ID)
+		plan.DomainCheckoutID = int64ValueOrZero(domainserver.DomainID)
+		plan.ProjectID = int64ValueOrZero(domainserver.ProjectID)
+		plan.StaticServerCheckoutID = int64ValueOrZero(domainserver.StaticServerID)
+		plan.TransientServerID = int64ValueOrZero(domainserver.TransientServerID)
+		plan.Subdomain = stringValueOrEmpty(domainserver.Subdomain)
+		plan.Endpoint = stringValueOrEmpty(domainserver.Endpoint)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -261,6 +280,7 @@ func (r *domainserverResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_domain_server", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -273,6 +293,14 @@ func (r *domainserverResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const querydomainserver = `query QueryDomainServerConnection ($id: bigint) {
 		domainServerConnection(where: {id: {_eq: $id}}) {
@@ -288,40 +316,34 @@ func (r *domainserverResource) Read(ctx context.Context, req resource.ReadReques
 	tflog.Debug(ctx, fmt.Sprintf("Reading domain server association: %v", state.ID))
 	request := graphql.NewRequest(querydomainserver)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		DomainServerConnection []ghostwriterDomainServerConnection `json:"domainServerConnection"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter domain server association ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"domainServerConnection": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domain Server Connection",
+			"Could not read Ghostwriter domain server association ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
 	// Overwrite items with refreshed state
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	domainservers := respData["domainServerConnection"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	domainservers := respData.DomainServerConnection
 	if len(domainservers) == 1 {
-		domainserver := domainservers[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(domainserver["id"].(float64)))
-		state.DomainCheckoutID = types.Int64Value(int64(domainserver["domainId"].(float64)))
-		state.ProjectID = types.Int64Value(int64(domainserver["projectId"].(float64)))
-		static_server_id, ok := domainserver["staticServerId"].(float64)
-		if !ok || domainserver["staticServerId"] == nil {
-			state.StaticServerCheckoutID = types.Int64Value(0)
-		} else {
-			state.StaticServerCheckoutID = types.Int64Value(int64(static_server_id))
-		}
-		cloud_server_id, ok := domainserver["transientServerId"].(float64)
-		if !ok || domainserver["transientServerId"] == nil {
-			state.TransientServerID = types.Int64Value(0)
-		} else {
-			state.TransientServerID = types.Int64Value(int64(cloud_server_id))
-		}
-		state.Subdomain = types.StringValue(domainserver["subdomain"].(string))
-		state.Endpoint = types.StringValue(domainserver["endpoint"].(string))
+		domainserver := domainservers[0]
+		state.ID = types.Int64Value(domainserver.ID)
+		state.DomainCheckoutID = int64ValueOrZero(domainserver.DomainID)
+		state.ProjectID = int64ValueOrZero(domainserver.ProjectID)
+		state.StaticServerCheckoutID = int64ValueOrZero(domainserver.StaticServerID)
+		state.TransientServerID = int64ValueOrZero(domainserver.TransientServerID)
+		state.Subdomain = stringValueOrEmpty(domainserver.Subdomain)
+		state.Endpoint = stringValueOrEmpty(domainserver.Endpoint)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter domain server association ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -345,6 +367,14 @@ func (r *domainserverResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	var updatedomain string
 	if plan.StaticServerCheckoutID.ValueInt64() != 0 {
@@ -392,7 +422,9 @@ func (r *domainserverResource) Update(ctx context.Context, req resource.UpdateRe
 	}
 	request.Var("subdomain", plan.Subdomain.ValueString())
 	request.Var("endpoint", plan.Endpoint.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateDomainServerConnection mutationResponse[ghostwriterDomainServerConnection] `json:"update_domainServerConnection"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Domain Server association",
@@ -401,27 +433,17 @@ func (r *domainserverResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	updated_domainservers := respData["update_domainServerConnection"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_domainservers := respData.UpdateDomainServerConnection.Returning
 	if len(updated_domainservers) == 1 {
-		domainserver := updated_domainservers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(domainserver["id"].(float64)))
-		plan.DomainCheckoutID = types.Int64Value(int64(domainserver["domainId"].(float64)))
-		plan.ProjectID = types.Int64Value(int64(domainserver["projectId"].(float64)))
-		static_server_id, ok := domainserver["staticServerId"].(float64)
-		if !ok || domainserver["staticServerId"] == nil {
-			plan.StaticServerCheckoutID = types.Int64Value(0)
-		} else {
-			plan.StaticServerCheckoutID = types.Int64Value(int64(static_server_id))
-		}
-		cloud_server_id, ok := domainserver["transientServerId"].(float64)
-		if !ok || domainserver["transientServerId"] == nil {
-			plan.TransientServerID = types.Int64Value(0)
-		} else {
-			plan.TransientServerID = types.Int64Value(int64(cloud_server_id))
-		}
-		plan.Subdomain = types.StringValue(domainserver["subdomain"].(string))
-		plan.Endpoint = types.StringValue(domainserver["endpoint"].(string))
+		domainserver := updated_domainservers[0]
+		plan.ID = types.Int64Value(domainserver.ID)
+		plan.DomainCheckoutID = int64ValueOrZero(domainserver.DomainID)
+		plan.ProjectID = int64ValueOrZero(domainserver.ProjectID)
+		plan.StaticServerCheckoutID = int64ValueOrZero(domainserver.StaticServerID)
+		plan.TransientServerID = int64ValueOrZero(domainserver.TransientServerID)
+		plan.Subdomain = stringValueOrEmpty(domainserver.Subdomain)
+		plan.Endpoint = stringValueOrEmpty(domainserver.Endpoint)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -437,6 +459,7 @@ func (r *domainserverResource) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_domain_server", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -449,10 +472,19 @@ func (r *domainserverResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
 	if state.ForceDelete.ValueBool() {
 		// Generate API request body from plan
 		const deletedomainserver = `mutation DeleteDomainServer ($id: bigint){
 			delete_domainServerConnection(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -460,12 +492,12 @@ func (r *domainserverResource) Delete(ctx context.Context, req resource.DeleteRe
 		}`
 		request := graphql.NewRequest(deletedomainserver)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
-			return
-		}
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Domain Server Connection", "domain server association ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
 	} else {
 		tflog.Info(ctx, "Cowardly refusing to delete domain server association. Association expiration will be managed by ghostwriter. Set force_delete to true to delete domain server connection.")
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_domain_server", req.State.Raw, tftypes.Value{})...)
 }
@@ -0,0 +1,324 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &domainsDataSource{}
+	_ datasource.DataSourceWithConfigure = &domainsDataSource{}
+)
+
+// NewdomainsDataSource is a helper function to simplify the provider implementation.
+func NewdomainsDataSource() datasource.DataSource {
+	return &domainsDataSource{}
+}
+
+// domainsDataSource is the data source implementation.
+type domainsDataSource struct {
+	client *ghostwriterClient
+}
+
+// domainsDataSourceModel maps the domains schema data.
+type domainsDataSourceModel struct {
+	Status        types.String             `tfsdk:"status"`
+	Registrar     types.String             `tfsdk:"registrar"`
+	ExpiresAfter  types.String             `tfsdk:"expires_after"`
+	ExpiresBefore types.String             `tfsdk:"expires_before"`
+	AutoRenew     types.Bool               `tfsdk:"auto_renew"`
+	HealthStatus  types.String             `tfsdk:"health_status"`
+	WhoisStatus   types.String             `tfsdk:"whois_status"`
+	NamePattern   types.String             `tfsdk:"name_pattern"`
+	Domains       []domainsDataSourceEntry `tfsdk:"domains"`
+}
+
+// domainsDataSourceEntry maps a single domain returned by the domains data source.
+type domainsDataSourceEntry struct {
+	ID                types.Int64  `tfsdk:"id"`
+	Name              types.String `tfsdk:"name"`
+	Registrar         types.String `tfsdk:"registrar"`
+	Creation          types.String `tfsdk:"creation"`
+	Expiration        types.String `tfsdk:"expiration"`
+	AutoRenew         types.Bool   `tfsdk:"auto_renew"`
+	BurnedExplanation types.String `tfsdk:"burned_explanation"`
+	Note              types.String `tfsdk:"note"`
+	VtPermalink       types.String `tfsdk:"vt_permalink"`
+	DomainStatusID    types.Int64  `tfsdk:"domain_status_id"`
+	HealthStatusID    types.Int64  `tfsdk:"health_status_id"`
+	WhoisStatusID     types.Int64  `tfsdk:"whois_status_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *domainsDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_domains"
+}
+
+// Configure adds the provider configured client to the datasource.
+func (d *domainsDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Schema defines the schema for the data source.
+func (d *domainsDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the domains registered in ghostwriter, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"status": schema.StringAttribute{
+				Description: "Only return domains with this Ghostwriter domain status. e.g. Available, Unavailable, Burned",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"registrar": schema.StringAttribute{
+				Description: "Only return domains registered with this registrar.",
+				Optional:    true,
+			},
+			"expires_after": schema.StringAttribute{
+				Description: "Only return domains expiring on or after this date. Format: YYYY-MM-DD.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"expires_before": schema.StringAttribute{
+				Description: "Only return domains expiring on or before this date. Format: YYYY-MM-DD.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"auto_renew": schema.BoolAttribute{
+				Description: "Only return domains with this auto-renew setting.",
+				Optional:    true,
+			},
+			"health_status": schema.StringAttribute{
+				Description: "Only return domains with this Ghostwriter health status. e.g. Healthy, Burned",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"whois_status": schema.StringAttribute{
+				Description: "Only return domains with this Ghostwriter WHOIS status. e.g. Enabled, Disabled",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"name_pattern": schema.StringAttribute{
+				Description: "Only return domains whose name matches this case-insensitive SQL LIKE pattern. e.g. %.com",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"domains": schema.ListNestedAttribute{
+				Description: "The matching domains, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The identifier of the domain.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The domain name.",
+							Computed:    true,
+						},
+						"registrar": schema.StringAttribute{
+							Description: "The domain registrar.",
+							Computed:    true,
+						},
+						"creation": schema.StringAttribute{
+							Description: "The domain creation date.",
+							Computed:    true,
+						},
+						"expiration": schema.StringAttribute{
+							Description: "The domain expiration date.",
+							Computed:    true,
+						},
+						"auto_renew": schema.BoolAttribute{
+							Description: "Whether the domain is set to auto-renew.",
+							Computed:    true,
+						},
+						"burned_explanation": schema.StringAttribute{
+							Description: "Explanation of why the domain was burned.",
+							Computed:    true,
+						},
+						"note": schema.StringAttribute{
+							Description: "Additional notes about the domain.",
+							Computed:    true,
+						},
+						"vt_permalink": schema.StringAttribute{
+							Description: "The VirusTotal permalink for the domain.",
+							Computed:    true,
+						},
+						"domain_status_id": schema.Int64Attribute{
+							Description: "The ID of the domain status.",
+							Computed:    true,
+						},
+						"health_status_id": schema.Int64Attribute{
+							Description: "The ID of the health status.",
+							Computed:    true,
+						},
+						"whois_status_id": schema.Int64Attribute{
+							Description: "The ID of the WHOIS status.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *domainsDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state domainsDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the Hasura filter from the configured attributes
+	where := map[string]any{}
+	status_filters := []struct {
+		attribute string
+		table     string
+		column    string
+		value     types.String
+	}{
+		{"status", "domainStatus", "domainStatusId", state.Status},
+		{"health_status", "healthStatus", "healthStatusId", state.HealthStatus},
+		{"whois_status", "whoisStatus", "whoisStatusId", state.WhoisStatus},
+	}
+	for _, filter := range status_filters {
+		if filter.value.IsNull() {
+			continue
+		}
+		status_id, err := d.client.lookupID(ctx, filter.table, filter.table, filter.value.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root(filter.attribute),
+				"Error Reading Ghostwriter Domains",
+				"Could not resolve "+filter.attribute+" "+filter.value.String()+": "+err.Error(),
+			)
+			continue
+		}
+		where[filter.column] = map[string]any{"_eq": status_id}
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !state.Registrar.IsNull() {
+		where["registrar"] = map[string]any{"_eq": state.Registrar.ValueString()}
+	}
+	if !state.AutoRenew.IsNull() {
+		where["autoRenew"] = map[string]any{"_eq": state.AutoRenew.ValueBool()}
+	}
+	if !state.NamePattern.IsNull() {
+		where["name"] = map[string]any{"_ilike": state.NamePattern.ValueString()}
+	}
+	expiration := map[string]any{}
+	if !state.ExpiresAfter.IsNull() {
+		expiration["_gte"] = state.ExpiresAfter.ValueString()
+	}
+	if !state.ExpiresBefore.IsNull() {
+		expiration["_lte"] = state.ExpiresBefore.ValueString()
+	}
+	if len(expiration) > 0 {
+		where["expiration"] = expiration
+	}
+
+	const querydomains = `query QueryDomains ($where: domain_bool_exp!){
+		domain(where: $where, order_by: {name: asc}) {
+			id,
+			burned_explanation,
+			autoRenew,
+			name,
+			registrar,
+			creation,
+			expiration,
+			note,
+			vtPermalink,
+			domainStatusId,
+			healthStatusId,
+			whoisStatusId
+		}
+	}`
+	request := graphql.NewRequest(querydomains)
+	request.Var("where", where)
+	var respData struct {
+		Domain []ghostwriterDomain `json:"domain"`
+	}
+	if err := d.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Domains",
+			"Could not read Ghostwriter domains: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	state.Domains = []domainsDataSourceEntry{}
+	for _, domain := range respData.Domain {
+		state.Domains = append(state.Domains, domainsDataSourceEntry{
+			ID:                types.Int64Value(domain.ID),
+			Name:              stringValueOrEmpty(domain.Name),
+			Registrar:         stringValueOrEmpty(domain.Registrar),
+			Creation:          stringValueOrEmpty(domain.Creation),
+			Expiration:        stringValueOrEmpty(domain.Expiration),
+			AutoRenew:         boolValueOrFalse(domain.AutoRenew),
+			BurnedExplanation: stringValueOrEmpty(domain.BurnedExplanation),
+			Note:              stringValueOrEmpty(domain.Note),
+			VtPermalink:       stringValueOrEmpty(domain.VtPermalink),
+			DomainStatusID:    int64ValueOrZero(domain.DomainStatusID),
+			HealthStatusID:    int64ValueOrZero(domain.HealthStatusID),
+			WhoisStatusID:     int64ValueOrZero(domain.WhoisStatusID),
+		})
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
@@ -0,0 +1,41 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestDomainsDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_domain" "test" {
+  name         = "domains-data-source.com"
+  registrar    = "Namecheap"
+  creation     = "2024-01-01"
+  expiration   = "2099-01-01"
+  auto_renew   = true
+  force_delete = true
+}
+
+data "ghostwriter_domains" "test" {
+  name_pattern  = "domains-data-source.%"
+  registrar     = "Namecheap"
+  expires_after = "2098-12-31"
+  auto_renew    = true
+  depends_on    = [ghostwriter_domain.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("data.ghostwriter_domains.test", "domains.#", "1"),
+					resource.TestCheckResourceAttr("data.ghostwriter_domains.test", "domains.0.name", "domains-data-source.com"),
+					resource.TestCheckResourceAttrPair("data.ghostwriter_domains.test", "domains.0.id", "ghostwriter_domain.test", "id"),
+				),
+			},
+		},
+	})
+}
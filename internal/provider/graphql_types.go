@@ -0,0 +1,284 @@
+package provider
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// mutationResponse maps the result of a Hasura insert, update or delete mutation.
+type mutationResponse[T any] struct {
+	AffectedRows int64 `json:"affected_rows"`
+	Returning    []T   `json:"returning"`
+}
+
+// checkoutResponse maps the result of the Ghostwriter checkoutDomain and checkoutServer actions.
+type checkoutResponse struct {
+	Result json.RawMessage `json:"result"`
+}
+
+// checkoutID returns the ID of the checkout created by the action, if the Ghostwriter
+// instance includes it in the result.
+func (c checkoutResponse) checkoutID() (int64, bool) {
+	var result struct {
+		ID *int64 `json:"id"`
+	}
+	if err := json.Unmarshal(c.Result, &result); err != nil || result.ID == nil {
+		return 0, false
+	}
+	return *result.ID, true
+}
+
+// ghostwriterWhoami maps the result of the Ghostwriter whoami action.
+type ghostwriterWhoami struct {
+	Username *string `json:"username"`
+	Role     *string `json:"role"`
+	Expires  *string `json:"expires"`
+}
+
+// ghostwriterDomain maps a row of the Ghostwriter domain table.
+type ghostwriterDomain struct {
+	ID                int64   `json:"id"`
+	Name              *string `json:"name"`
+	Registrar         *string `json:"registrar"`
+	Creation          *string `json:"creation"`
+	Expiration        *string `json:"expiration"`
+	AutoRenew         *bool   `json:"autoRenew"`
+	BurnedExplanation *string `json:"burned_explanation"`
+	Note              *string `json:"note"`
+	VtPermalink       *string `json:"vtPermalink"`
+	DomainStatusID    *int64  `json:"domainStatusId"`
+	HealthStatusID    *int64  `json:"healthStatusId"`
+	WhoisStatusID     *int64  `json:"whoisStatusId"`
+}
+
+// ghostwriterDomainCheckout maps a row of the Ghostwriter domainCheckout table.
+type ghostwriterDomainCheckout struct {
+	ID             int64   `json:"id"`
+	DomainID       *int64  `json:"domainId"`
+	ProjectID      *int64  `json:"projectId"`
+	ActivityTypeID *int64  `json:"activityTypeId"`
+	StartDate      *string `json:"startDate"`
+	EndDate        *string `json:"endDate"`
+	Note           *string `json:"note"`
+}
+
+// ghostwriterStaticServer maps a row of the Ghostwriter staticServer table.
+type ghostwriterStaticServer struct {
+	ID               int64   `json:"id"`
+	Name             *string `json:"name"`
+	ServerProviderID *int64  `json:"serverProviderId"`
+	ServerStatusID   *int64  `json:"serverStatusId"`
+	IpAddress        *string `json:"ipAddress"`
+	Note             *string `json:"note"`
+}
+
+// ghostwriterAuxServerAddress maps a row of the Ghostwriter auxServerAddress table.
+type ghostwriterAuxServerAddress struct {
+	ID             int64   `json:"id"`
+	StaticServerID *int64  `json:"staticServerId"`
+	IpAddress      *string `json:"ipAddress"`
+}
+
+// ghostwriterServerCheckout maps a row of the Ghostwriter serverCheckout table.
+type ghostwriterServerCheckout struct {
+	ID             int64   `json:"id"`
+	ServerID       *int64  `json:"serverId"`
+	ProjectID      *int64  `json:"projectId"`
+	ActivityTypeID *int64  `json:"activityTypeId"`
+	ServerRoleID   *int64  `json:"serverRoleId"`
+	StartDate      *string `json:"startDate"`
+	EndDate        *string `json:"endDate"`
+	Note           *string `json:"note"`
+}
+
+// ghostwriterCloudServer maps a row of the Ghostwriter cloudServer table.
+type ghostwriterCloudServer struct {
+	ID               int64    `json:"id"`
+	Name             *string  `json:"name"`
+	ServerProviderID *int64   `json:"serverProviderId"`
+	ActivityTypeID   *int64   `json:"activityTypeId"`
+	IpAddress        *string  `json:"ipAddress"`
+	AuxAddress       []string `json:"auxAddress"`
+	ProjectID        *int64   `json:"projectId"`
+	Note             *string  `json:"note"`
+	ServerRoleID     *int64   `json:"serverRoleId"`
+}
+
+// ghostwriterDomainServerConnection maps a row of the Ghostwriter domainServerConnection table.
+type ghostwriterDomainServerConnection struct {
+	ID                int64   `json:"id"`
+	DomainID          *int64  `json:"domainId"`
+	ProjectID         *int64  `json:"projectId"`
+	StaticServerID    *int64  `json:"staticServerId"`
+	TransientServerID *int64  `json:"transientServerId"`
+	Subdomain         *string `json:"subdomain"`
+	Endpoint          *string `json:"endpoint"`
+}
+
+// ghostwriterOplog maps a row of the Ghostwriter oplog table.
+type ghostwriterOplog struct {
+	ID        int64   `json:"id"`
+	Name      *string `json:"name"`
+	ProjectID *int64  `json:"projectId"`
+}
+
+// ghostwriterOplogEntry maps a row of the Ghostwriter oplogEntry table.
+type ghostwriterOplogEntry struct {
+	ID           int64   `json:"id"`
+	Oplog        *int64  `json:"oplog"`
+	StartDate    *string `json:"startDate"`
+	EndDate      *string `json:"endDate"`
+	SourceIp     *string `json:"sourceIp"`
+	DestIp       *string `json:"destIp"`
+	Tool         *string `json:"tool"`
+	UserContext  *string `json:"userContext"`
+	Command      *string `json:"command"`
+	Description  *string `json:"description"`
+	Output       *string `json:"output"`
+	Comments     *string `json:"comments"`
+	OperatorName *string `json:"operatorName"`
+}
+
+// ghostwriterProject maps a row of the Ghostwriter project table.
+type ghostwriterProject struct {
+	ID            int64   `json:"id"`
+	ClientID      *int64  `json:"clientId"`
+	OperatorID    *int64  `json:"operatorId"`
+	ProjectTypeID *int64  `json:"projectTypeId"`
+	Codename      *string `json:"codename"`
+	Complete      *bool   `json:"complete"`
+	StartDate     *string `json:"startDate"`
+	StartTime     *string `json:"startTime"`
+	EndDate       *string `json:"endDate"`
+	EndTime       *string `json:"endTime"`
+	Timezone      *string `json:"timezone"`
+	Note          *string `json:"note"`
+	SlackChannel  *string `json:"slackChannel"`
+}
+
+// ghostwriterClientRow maps a row of the Ghostwriter client table.
+type ghostwriterClientRow struct {
+	ID        int64   `json:"id"`
+	Name      *string `json:"name"`
+	ShortName *string `json:"shortName"`
+	Codename  *string `json:"codename"`
+	Address   *string `json:"address"`
+	Timezone  *string `json:"timezone"`
+	Note      *string `json:"note"`
+}
+
+// ghostwriterProjectAssignment maps a row of the Ghostwriter projectAssignment table along with
+// the names of the assigned user and their project role.
+type ghostwriterProjectAssignment struct {
+	ID         int64   `json:"id"`
+	ProjectID  *int64  `json:"projectId"`
+	OperatorID *int64  `json:"operatorId"`
+	RoleID     *int64  `json:"roleId"`
+	StartDate  *string `json:"startDate"`
+	EndDate    *string `json:"endDate"`
+	Note       *string `json:"note"`
+	User       *struct {
+		Username *string `json:"username"`
+	} `json:"user"`
+	ProjectRole *struct {
+		ProjectRole *string `json:"projectRole"`
+	} `json:"projectRole"`
+}
+
+// ghostwriterActivityType maps a row of the Ghostwriter activityType table.
+type ghostwriterActivityType struct {
+	ID       int64   `json:"id"`
+	Activity *string `json:"activity"`
+}
+
+// ghostwriterServerRole maps a row of the Ghostwriter serverRole table.
+type ghostwriterServerRole struct {
+	ID         int64   `json:"id"`
+	ServerRole *string `json:"serverRole"`
+}
+
+// ghostwriterServerProvider maps a row of the Ghostwriter serverProvider table.
+type ghostwriterServerProvider struct {
+	ID             int64   `json:"id"`
+	ServerProvider *string `json:"serverProvider"`
+}
+
+// stringValueOrEmpty converts a nullable Ghostwriter column into a Terraform string, mapping null to "".
+func stringValueOrEmpty(value *string) types.String {
+	if value == nil {
+		return types.StringValue("")
+	}
+	return types.StringValue(*value)
+}
+
+// int64ValueOrZero converts a nullable Ghostwriter column into a Terraform number, mapping null to 0.
+func int64ValueOrZero(value *int64) types.Int64 {
+	if value == nil {
+		return types.Int64Value(0)
+	}
+	return types.Int64Value(*value)
+}
+
+// boolValueOrFalse converts a nullable Ghostwriter column into a Terraform bool, mapping null to false.
+func boolValueOrFalse(value *bool) types.Bool {
+	if value == nil {
+		return types.BoolValue(false)
+	}
+	return types.BoolValue(*value)
+}
+
+// stringListValue converts a nullable Ghostwriter array column into a list of Terraform strings.
+func stringListValue(values []string) []types.String {
+	list := []types.String{}
+	for _, value := range values {
+		list = append(list, types.StringValue(value))
+	}
+	return list
+}
+
+// nullableString converts a Terraform string into a Ghostwriter column value, mapping null,
+// unknown and "" to null as the inverse of stringValueOrEmpty.
+func nullableString(value types.String) *string {
+	if value.IsNull() || value.IsUnknown() || value.ValueString() == "" {
+		return nil
+	}
+	return value.ValueStringPointer()
+}
+
+// nullableInt64 converts a Terraform number into a Ghostwriter column value, mapping null,
+// unknown and 0 to null as the inverse of int64ValueOrZero.
+func nullableInt64(value types.Int64) *int64 {
+	if value.IsNull() || value.IsUnknown() || value.ValueInt64() == 0 {
+		return nil
+	}
+	return value.ValueInt64Pointer()
+}
+
+// timestampValue converts a Ghostwriter timestamp column into a Terraform string. Hasura
+// normalises timestamps, so the current value is kept when it refers to the same instant.
+func timestampValue(current types.String, value *string) types.String {
+	if value == nil {
+		return types.StringValue("")
+	}
+	if !current.IsNull() && !current.IsUnknown() {
+		previous, err := time.Parse(time.RFC3339, current.ValueString())
+		if err == nil {
+			if updated, err := time.Parse(time.RFC3339, *value); err == nil && previous.Equal(updated) {
+				return current
+			}
+		}
+	}
+	return types.StringValue(*value)
+}
+
+// responseString renders a decoded Ghostwriter response as JSON for debug logging.
+func responseString(response any) string {
+	encoded, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Sprintf("%v", response)
+	}
+	return string(encoded)
+}
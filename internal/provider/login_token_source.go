@@ -0,0 +1,128 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/machinebox/graphql"
+	"golang.org/x/oauth2"
+)
+
+// loginTokenEarlyExpiry is how long before the reported expiry a Ghostwriter token is refreshed.
+const loginTokenEarlyExpiry = time.Minute
+
+// loginTokenSource obtains a Ghostwriter JWT with the login mutation and caches it until it is
+// about to expire.
+type loginTokenSource struct {
+	client   *graphql.Client
+	username string
+	password string
+
+	mu    sync.Mutex
+	token *oauth2.Token
+}
+
+// newLoginTokenSource returns a token source that logs in with the given credentials and
+// logs in again whenever the previous token is about to expire.
+func newLoginTokenSource(client *graphql.Client, username string, password string) *loginTokenSource {
+	return &loginTokenSource{
+		client:   client,
+		username: username,
+		password: password,
+	}
+}
+
+// loginError is returned when the login mutation fails, so that it can be told apart from
+// errors returned by the request the token was needed for.
+type loginError struct {
+	err error
+}
+
+func (e *loginError) Error() string { return e.err.Error() }
+
+func (e *loginError) Unwrap() error { return e.err }
+
+// Token returns the cached token, logging in to Ghostwriter when there is none or it is about
+// to expire. The login is bound to ctx, so it is cancelled along with the request that needed it.
+func (s *loginTokenSource) Token(ctx context.Context) (*oauth2.Token, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.token != nil && (s.token.Expiry.IsZero() || time.Until(s.token.Expiry) > loginTokenEarlyExpiry) {
+		return s.token, nil
+	}
+
+	token, err := s.login(ctx)
+	if err != nil {
+		return nil, &loginError{err: err}
+	}
+	s.token = token
+	return token, nil
+}
+
+// requestTokenSource adapts a loginTokenSource to oauth2.TokenSource for a single request.
+type requestTokenSource struct {
+	ctx    context.Context
+	source *loginTokenSource
+}
+
+func (s requestTokenSource) Token() (*oauth2.Token, error) { return s.source.Token(s.ctx) }
+
+// loginTransport is an http.RoundTripper that authorizes every request with a token from a
+// loginTokenSource, logging in under the context of the request being sent.
+type loginTransport struct {
+	source *loginTokenSource
+	next   http.RoundTripper
+}
+
+// newLoginTransport wraps next with a loginTransport.
+func newLoginTransport(source *loginTokenSource, next http.RoundTripper) *loginTransport {
+	return &loginTransport{source: source, next: next}
+}
+
+// RoundTrip sends the request with an Authorization header for the current token.
+func (t *loginTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	transport := &oauth2.Transport{
+		Source: requestTokenSource{ctx: req.Context(), source: t.source},
+		Base:   t.next,
+	}
+	return transport.RoundTrip(req)
+}
+
+// login runs the login mutation with the configured credentials.
+func (s *loginTokenSource) login(ctx context.Context) (*oauth2.Token, error) {
+	const login = `mutation Login ($username: String!, $password: String!) {
+		login(username: $username, password: $password) {
+			token
+			expires
+		}
+	}`
+	request := graphql.NewRequest(login)
+	request.Var("username", s.username)
+	request.Var("password", s.password)
+	var respData struct {
+		Login *struct {
+			Token   *string `json:"token"`
+			Expires *string `json:"expires"`
+		} `json:"login"`
+	}
+	if err := s.client.Run(ctx, request, &respData); err != nil {
+		return nil, fmt.Errorf("logging in to Ghostwriter as %s: %w", s.username, err)
+	}
+	if respData.Login == nil || respData.Login.Token == nil || *respData.Login.Token == "" {
+		return nil, errors.New("logging in to Ghostwriter as " + s.username + ": no token was returned")
+	}
+
+	token := &oauth2.Token{AccessToken: *respData.Login.Token}
+	if respData.Login.Expires != nil {
+		expires, err := time.Parse(time.RFC3339Nano, *respData.Login.Expires)
+		if err != nil {
+			return nil, fmt.Errorf("parsing Ghostwriter token expiry %q: %w", *respData.Login.Expires, err)
+		}
+		token.Expiry = expires
+	}
+	return token, nil
+}
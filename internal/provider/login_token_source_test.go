@@ -0,0 +1,68 @@
+package provider
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/machinebox/graphql"
+)
+
+func TestLoginTransport(t *testing.T) {
+	var logins int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") == "" {
+			atomic.AddInt32(&logins, 1)
+			expires := time.Now().Add(time.Hour).Format(time.RFC3339Nano)
+			_, _ = w.Write([]byte(`{"data": {"login": {"token": "jwt", "expires": "` + expires + `"}}}`))
+			return
+		}
+		if r.Header.Get("Authorization") != "Bearer jwt" {
+			t.Errorf("unexpected Authorization header %q", r.Header.Get("Authorization"))
+		}
+		_, _ = w.Write([]byte(`{"data": {}}`))
+	}))
+	defer server.Close()
+
+	src := newLoginTokenSource(graphql.NewClient(server.URL), "user", "password")
+	client := graphql.NewClient(server.URL, graphql.WithHTTPClient(&http.Client{Transport: newLoginTransport(src, http.DefaultTransport)}))
+	for i := 0; i < 2; i++ {
+		var respData struct{}
+		if err := client.Run(context.Background(), graphql.NewRequest(`query Whoami { whoami { username } }`), &respData); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+	if logins := atomic.LoadInt32(&logins); logins != 1 {
+		t.Errorf("expected 1 login, got %d", logins)
+	}
+}
+
+func TestLoginTokenSourceContext(t *testing.T) {
+	release := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+
+	src := newLoginTokenSource(graphql.NewClient(server.URL), "user", "password")
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := src.Token(ctx)
+	var login_err *loginError
+	if !errors.As(err, &login_err) {
+		t.Fatalf("expected a login error, got %v", err)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("expected the login to stop at the context deadline, got %s", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("expected the login to be cancelled, it took %s", elapsed)
+	}
+}
@@ -0,0 +1,38 @@
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// runDeleteMutation runs a mutation issued by a resource Delete and reports an error if it
+// fails or affects no rows, so that Terraform only forgets a resource once Ghostwriter has
+// actually removed or released it. The mutation must select affected_rows. action is what the
+// mutation does to the record, e.g. "delete" or "release", and target describes the affected
+// record in the diagnostic, e.g. "domain ID 4".
+func runDeleteMutation(ctx context.Context, client *ghostwriterClient, request *graphql.Request, action string, summary string, target string) diag.Diagnostics {
+	var diags diag.Diagnostics
+	var respData map[string]mutationResponse[json.RawMessage]
+	if err := client.Run(ctx, request, &respData); err != nil {
+		diags.AddError(summary, "Could not "+action+" "+target+": "+err.Error())
+		return diags
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	for _, result := range respData {
+		if result.AffectedRows == 0 {
+			diags.AddError(
+				summary,
+				"Ghostwriter did not modify any rows for "+target+". "+
+					"It may have been removed outside of Terraform or the credentials may not be permitted to change it, "+
+					"refresh the state to remove it if it no longer exists.",
+			)
+		}
+	}
+	return diags
+}
@@ -0,0 +1,75 @@
+package provider
+
+import (
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// The on_destroy modes of the checkout resources.
+const (
+	onDestroyRelease   = "release"
+	onDestroyBurn      = "burn"
+	onDestroyExpireNow = "expire_now"
+	onDestroyDelete    = "delete"
+)
+
+// onDestroyModes lists the accepted values of on_destroy.
+var onDestroyModes = []string{onDestroyRelease, onDestroyBurn, onDestroyExpireNow, onDestroyDelete}
+
+// onDestroyAuditActions maps the on_destroy modes to the actions recorded in the audit oplog.
+var onDestroyAuditActions = map[string]string{
+	onDestroyRelease:   "release",
+	onDestroyBurn:      "burn",
+	onDestroyExpireNow: "expire",
+	onDestroyDelete:    "delete",
+}
+
+// burnedStatus is the status burned domains are set to.
+const burnedStatus = "Burned"
+
+// checkedOutStatus is the status Ghostwriter sets domains to while they are checked out.
+const checkedOutStatus = "Unavailable"
+
+// unavailableServerStatus is the status burned servers are set to, as servers have no burned status.
+const unavailableServerStatus = "Unavailable"
+
+// onDestroyMode returns the on_destroy mode of a checkout. Checkouts without on_destroy keep the
+// force_delete behaviour they were created with.
+func onDestroyMode(on_destroy types.String, force_delete types.Bool) string {
+	if !on_destroy.IsNull() && !on_destroy.IsUnknown() && on_destroy.ValueString() != "" {
+		return on_destroy.ValueString()
+	}
+	if force_delete.ValueBool() {
+		return onDestroyDelete
+	}
+	return onDestroyRelease
+}
+
+// validateOnDestroy checks a burn_explanation is given when checkouts are burned on destroy.
+func validateOnDestroy(on_destroy types.String, burn_explanation types.String) diag.Diagnostics {
+	var diags diag.Diagnostics
+	if on_destroy.IsUnknown() || on_destroy.ValueString() != onDestroyBurn || burn_explanation.IsUnknown() {
+		return diags
+	}
+	if burn_explanation.ValueString() == "" {
+		diags.AddAttributeError(
+			path.Root("burn_explanation"),
+			"Missing Burn Explanation",
+			"burn_explanation must be set when on_destroy is burn, so the Ghostwriter record shows why it was burned.",
+		)
+	}
+	return diags
+}
+
+// checkoutExpiryDate returns the end date of a checkout expired today. Checkouts that have not
+// started yet end on their start date, so they never end before they start.
+func checkoutExpiryDate(start_date types.String) string {
+	today := time.Now().Format(time.DateOnly)
+	if start_date.ValueString() > today {
+		return start_date.ValueString()
+	}
+	return today
+}
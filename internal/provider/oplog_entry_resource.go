@@ -0,0 +1,524 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                = &oplogEntryResource{}
+	_ resource.ResourceWithConfigure   = &oplogEntryResource{}
+	_ resource.ResourceWithImportState = &oplogEntryResource{}
+)
+
+// NewoplogEntryResource is a helper function to simplify the provider implementation.
+func NewoplogEntryResource() resource.Resource {
+	return &oplogEntryResource{}
+}
+
+// oplogEntryResource is the resource implementation.
+type oplogEntryResource struct {
+	client *ghostwriterClient
+}
+
+// oplogEntryResourceModel maps the resource schema data.
+type oplogEntryResourceModel struct {
+	ID           types.Int64    `tfsdk:"id"`
+	OplogID      types.Int64    `tfsdk:"oplog_id"`
+	StartDate    types.String   `tfsdk:"start_date"`
+	EndDate      types.String   `tfsdk:"end_date"`
+	SourceIp     types.String   `tfsdk:"source_ip"`
+	DestIp       types.String   `tfsdk:"dest_ip"`
+	Tool         types.String   `tfsdk:"tool"`
+	UserContext  types.String   `tfsdk:"user_context"`
+	Command      types.String   `tfsdk:"command"`
+	Description  types.String   `tfsdk:"description"`
+	Output       types.String   `tfsdk:"output"`
+	Comments     types.String   `tfsdk:"comments"`
+	OperatorName types.String   `tfsdk:"operator_name"`
+	ForceDelete  types.Bool     `tfsdk:"force_delete"`
+	LastUpdated  types.String   `tfsdk:"last_updated"`
+	Timeouts     timeouts.Value `tfsdk:"timeouts"`
+}
+
+// oplogEntryTimestamp matches the RFC 3339 timestamps accepted for start_date and end_date.
+var oplogEntryTimestamp = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}T\d{2}:\d{2}:\d{2}(\.\d+)?(Z|[+-]\d{2}:\d{2})$`)
+
+// Metadata returns the resource type name.
+func (r *oplogEntryResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_oplog_entry"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *oplogEntryResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *oplogEntryResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create an entry in an operations log.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the oplog entry.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the oplog entry.",
+				Computed:    true,
+			},
+			"oplog_id": schema.Int64Attribute{
+				Description: "The unique identifier of the oplog the entry belongs to.",
+				Required:    true,
+			},
+			"start_date": schema.StringAttribute{
+				Description: "When the activity started, in RFC 3339 format. Defaults to the time the entry is created.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						oplogEntryTimestamp,
+						"Timestamp must be in RFC 3339 format. e.g. 2022-01-01T09:00:00Z",
+					),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Description: "When the activity finished, in RFC 3339 format. Defaults to the time the entry is created.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						oplogEntryTimestamp,
+						"Timestamp must be in RFC 3339 format. e.g. 2022-01-01T09:00:00Z",
+					),
+				},
+			},
+			"source_ip": schema.StringAttribute{
+				Description: "The source IP address or hostname of the activity.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"dest_ip": schema.StringAttribute{
+				Description: "The destination IP address or hostname of the activity.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"tool": schema.StringAttribute{
+				Description: "The tool used for the activity, e.g. terraform.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"user_context": schema.StringAttribute{
+				Description: "The user context the activity was performed as.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"command": schema.StringAttribute{
+				Description: "The command that was run.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"description": schema.StringAttribute{
+				Description: "A description of the activity.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"output": schema.StringAttribute{
+				Description: "The output of the command.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"comments": schema.StringAttribute{
+				Description: "Comments about the activity.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"operator_name": schema.StringAttribute{
+				Description: "The name of the operator who performed the activity.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"force_delete": schema.BoolAttribute{
+				Description: "If false, the entry will not be deleted from the oplog when not managed by terraform. If true, the entry will be hard-deleted from the ghostwriter instance. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ImportState imports the resource state from Terraform state.
+func (r *oplogEntryResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	tflog.Debug(ctx, fmt.Sprintf("Importing oplog entry resource ID: %s", req.ID))
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Import ID",
+			"Could not parse import ID: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_delete"), false)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *oplogEntryResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan oplogEntryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	now := time.Now().UTC().Format(time.RFC3339)
+	if plan.StartDate.IsUnknown() {
+		plan.StartDate = types.StringValue(now)
+	}
+	if plan.EndDate.IsUnknown() {
+		plan.EndDate = types.StringValue(now)
+	}
+
+	// Generate API request body from plan
+	const insertoplogentry = `mutation InsertOplogEntry ($oplog: bigint, $start_date: timestamptz, $end_date: timestamptz, $source_ip: String, $dest_ip: String, $tool: String, $user_context: String, $command: String, $description: String, $output: String, $comments: String, $operator_name: String){
+		insert_oplogEntry(objects: {oplog: $oplog, startDate: $start_date, endDate: $end_date, sourceIp: $source_ip, destIp: $dest_ip, tool: $tool, userContext: $user_context, command: $command, description: $description, output: $output, comments: $comments, operatorName: $operator_name}) {
+			returning {
+				id
+				oplog
+				startDate
+				endDate
+				sourceIp
+				destIp
+				tool
+				userContext
+				command
+				description
+				output
+				comments
+				operatorName
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Creating oplog entry: %v", plan))
+	request := graphql.NewRequest(insertoplogentry)
+	plan.setRequestVars(request)
+	var respData struct {
+		InsertOplogEntry mutationResponse[ghostwriterOplogEntry] `json:"insert_oplogEntry"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating oplog entry",
+			"Could not create oplog entry, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	entries := respData.InsertOplogEntry.Returning
+	if len(entries) == 1 {
+		plan.setOplogEntry(entries[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error creating oplog entry",
+			"Could not create oplog entry: Oplog entry not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *oplogEntryResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state oplogEntryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const queryoplogentry = `query QueryOplogEntry ($id: bigint){
+		oplogEntry(where: {id: {_eq: $id}}) {
+			id
+			oplog
+			startDate
+			endDate
+			sourceIp
+			destIp
+			tool
+			userContext
+			command
+			description
+			output
+			comments
+			operatorName
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Reading oplog entry: %v", state.ID))
+	request := graphql.NewRequest(queryoplogentry)
+	request.Var("id", state.ID.ValueInt64())
+	var respData struct {
+		OplogEntry []ghostwriterOplogEntry `json:"oplogEntry"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Oplog Entry",
+			"Could not read Ghostwriter oplog entry ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	entries := respData.OplogEntry
+	if len(entries) == 1 {
+		state.setOplogEntry(entries[0])
+
+		// Set refreshed state
+		diags = resp.State.Set(ctx, &state)
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter oplog entry ID %v not found, removing from state", state.ID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *oplogEntryResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan oplogEntryResourceModel
+	var state oplogEntryResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(stateDiags...)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const updateoplogentry = `mutation UpdateOplogEntry ($id: bigint, $oplog: bigint, $start_date: timestamptz, $end_date: timestamptz, $source_ip: String, $dest_ip: String, $tool: String, $user_context: String, $command: String, $description: String, $output: String, $comments: String, $operator_name: String){
+		update_oplogEntry(where: {id: {_eq: $id}}, _set: {oplog: $oplog, startDate: $start_date, endDate: $end_date, sourceIp: $source_ip, destIp: $dest_ip, tool: $tool, userContext: $user_context, command: $command, description: $description, output: $output, comments: $comments, operatorName: $operator_name}) {
+			returning {
+				id
+				oplog
+				startDate
+				endDate
+				sourceIp
+				destIp
+				tool
+				userContext
+				command
+				description
+				output
+				comments
+				operatorName
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Updating oplog entry: %v", plan))
+	request := graphql.NewRequest(updateoplogentry)
+	request.Var("id", state.ID.ValueInt64())
+	plan.setRequestVars(request)
+	var respData struct {
+		UpdateOplogEntry mutationResponse[ghostwriterOplogEntry] `json:"update_oplogEntry"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Oplog Entry",
+			"Could not update oplog entry ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_entries := respData.UpdateOplogEntry.Returning
+	if len(updated_entries) == 1 {
+		plan.setOplogEntry(updated_entries[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Oplog Entry",
+			"Could not update oplog entry ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": oplog entry not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *oplogEntryResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state oplogEntryResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	if state.ForceDelete.ValueBool() {
+		// Generate API request body from plan
+		const deleteoplogentry = `mutation DeleteOplogEntry ($id: bigint){
+			delete_oplogEntry(where: {id: {_eq: $id}}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		request := graphql.NewRequest(deleteoplogentry)
+		request.Var("id", state.ID.ValueInt64())
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Oplog Entry", "oplog entry ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+	} else {
+		tflog.Info(ctx, "Cowardly refusing to delete oplog entry. The entry will remain in the oplog. Set force_delete to true to delete oplog entry.")
+		return
+	}
+}
+
+// setRequestVars sets the variables shared by the insert and update oplog entry mutations.
+func (m *oplogEntryResourceModel) setRequestVars(request *graphql.Request) {
+	request.Var("oplog", m.OplogID.ValueInt64())
+	request.Var("start_date", m.StartDate.ValueString())
+	request.Var("end_date", m.EndDate.ValueString())
+	request.Var("source_ip", m.SourceIp.ValueString())
+	request.Var("dest_ip", m.DestIp.ValueString())
+	request.Var("tool", m.Tool.ValueString())
+	request.Var("user_context", m.UserContext.ValueString())
+	request.Var("command", m.Command.ValueString())
+	request.Var("description", m.Description.ValueString())
+	request.Var("output", m.Output.ValueString())
+	request.Var("comments", m.Comments.ValueString())
+	request.Var("operator_name", m.OperatorName.ValueString())
+}
+
+// setOplogEntry copies an oplog entry row returned by Ghostwriter into the model.
+func (m *oplogEntryResourceModel) setOplogEntry(entry ghostwriterOplogEntry) {
+	m.ID = types.Int64Value(entry.ID)
+	m.OplogID = int64ValueOrZero(entry.Oplog)
+	m.StartDate = timestampValue(m.StartDate, entry.StartDate)
+	m.EndDate = timestampValue(m.EndDate, entry.EndDate)
+	m.SourceIp = stringValueOrEmpty(entry.SourceIp)
+	m.DestIp = stringValueOrEmpty(entry.DestIp)
+	m.Tool = stringValueOrEmpty(entry.Tool)
+	m.UserContext = stringValueOrEmpty(entry.UserContext)
+	m.Command = stringValueOrEmpty(entry.Command)
+	m.Description = stringValueOrEmpty(entry.Description)
+	m.Output = stringValueOrEmpty(entry.Output)
+	m.Comments = stringValueOrEmpty(entry.Comments)
+	m.OperatorName = stringValueOrEmpty(entry.OperatorName)
+}
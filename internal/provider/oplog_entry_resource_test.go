@@ -0,0 +1,86 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestOplogEntryResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+resource "ghostwriter_oplog" "test" {
+  name = "Test Oplog"
+  project_id = data.ghostwriter_project.testproject.id
+  force_delete = true
+}
+
+resource "ghostwriter_oplog_entry" "test" {
+  oplog_id = ghostwriter_oplog.test.id
+  start_date = "2024-01-01T09:00:00Z"
+  end_date = "2024-01-01T09:05:00Z"
+  dest_ip = "203.0.113.10"
+  tool = "terraform"
+  description = "Redirector created"
+  force_delete = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("ghostwriter_oplog_entry.test", "oplog_id", "ghostwriter_oplog.test", "id"),
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "start_date", "2024-01-01T09:00:00Z"),
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "dest_ip", "203.0.113.10"),
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "tool", "terraform"),
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "description", "Redirector created"),
+					resource.TestCheckResourceAttrSet("ghostwriter_oplog_entry.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_oplog_entry.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:      "ghostwriter_oplog_entry.test",
+				ImportState:       true,
+				ImportStateVerify: true,
+				// Ghostwriter returns timestamps with an explicit offset.
+				ImportStateVerifyIgnore: []string{"force_delete", "last_updated", "start_date", "end_date"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+resource "ghostwriter_oplog" "test" {
+  name = "Test Oplog"
+  project_id = data.ghostwriter_project.testproject.id
+  force_delete = true
+}
+
+resource "ghostwriter_oplog_entry" "test" {
+  oplog_id = ghostwriter_oplog.test.id
+  start_date = "2024-01-01T09:00:00Z"
+  end_date = "2024-01-01T09:05:00Z"
+  dest_ip = "203.0.113.11"
+  tool = "terraform"
+  description = "Redirector IP changed"
+  force_delete = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "dest_ip", "203.0.113.11"),
+					resource.TestCheckResourceAttr("ghostwriter_oplog_entry.test", "description", "Redirector IP changed"),
+					resource.TestCheckResourceAttrSet("ghostwriter_oplog_entry.test", "last_updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
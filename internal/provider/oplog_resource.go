@@ -6,13 +6,17 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
@@ -31,16 +35,17 @@ func NewoplogResource() resource.Resource {
 
 // oplogResource is the resource implementation.
 type oplogResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type oplogResourceModel struct {
-	ID          types.Int64  `tfsdk:"id"`
-	Name        types.String `tfsdk:"name"`
-	ProjectID   types.Int64  `tfsdk:"project_id"`
-	ForceDelete types.Bool   `tfsdk:"force_delete"`
-	LastUpdated types.String `tfsdk:"last_updated"`
+	ID          types.Int64    `tfsdk:"id"`
+	Name        types.String   `tfsdk:"name"`
+	ProjectID   types.Int64    `tfsdk:"project_id"`
+	ForceDelete types.Bool     `tfsdk:"force_delete"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -56,12 +61,12 @@ func (r *oplogResource) Configure(_ context.Context, req resource.ConfigureReque
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -71,13 +76,16 @@ func (r *oplogResource) Configure(_ context.Context, req resource.ConfigureReque
 }
 
 // Schema defines the schema for the resource.
-func (r *oplogResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *oplogResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Create an operations log.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the oplog.",
@@ -93,6 +101,9 @@ func (r *oplogResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 			"project_id": schema.Int64Attribute{
 				Description: "The unique identifier of the project the oplog should be created for.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"force_delete": schema.BoolAttribute{
 				Description: "If false, will not be deleted from the ghostwriter instance when not managed by terraform. If true, the oplog will be hard-deleted from the ghostwriter instance. Default is false.",
@@ -101,6 +112,14 @@ func (r *oplogResource) Schema(_ context.Context, _ resource.SchemaRequest, resp
 				Default:     booldefault.StaticBool(false),
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
 	}
 }
 
@@ -130,6 +149,14 @@ func (r *oplogResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const insertoplog = `mutation InsertOplog ($name: String, $project_id: bigint){
 		insert_oplog(objects: {name: $name, projectId: $project_id}) {
@@ -144,7 +171,9 @@ func (r *oplogResource) Create(ctx context.Context, req resource.CreateRequest,
 	request := graphql.NewRequest(insertoplog)
 	request.Var("name", plan.Name.ValueString())
 	request.Var("project_id", plan.ProjectID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		InsertOplog mutationResponse[ghostwriterOplog] `json:"insert_oplog"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating oplog",
@@ -153,13 +182,13 @@ func (r *oplogResource) Create(ctx context.Context, req resource.CreateRequest,
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	oplogs := respData["insert_oplog"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	oplogs := respData.InsertOplog.Returning
 	if len(oplogs) == 1 {
-		oplog := oplogs[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(oplog["id"].(float64)))
-		plan.Name = types.StringValue(oplog["name"].(string))
-		plan.ProjectID = types.Int64Value(int64(oplog["projectId"].(float64)))
+		oplog := oplogs[0]
+		plan.ID = types.Int64Value(oplog.ID)
+		plan.Name = stringValueOrEmpty(oplog.Name)
+		plan.ProjectID = int64ValueOrZero(oplog.ProjectID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -175,6 +204,7 @@ func (r *oplogResource) Create(ctx context.Context, req resource.CreateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_oplog", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -187,6 +217,14 @@ func (r *oplogResource) Read(ctx context.Context, req resource.ReadRequest, resp
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const queryoplog = `query QueryOplog ($id: bigint){
 		oplog(where: {id: {_eq: $id}}) {
@@ -198,26 +236,30 @@ func (r *oplogResource) Read(ctx context.Context, req resource.ReadRequest, resp
 	tflog.Debug(ctx, fmt.Sprintf("Reading oplog: %v", state.ID))
 	request := graphql.NewRequest(queryoplog)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		Oplog []ghostwriterOplog `json:"oplog"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter oplog ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"oplog": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Oplog",
+			"Could not read Ghostwriter oplog ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
 	// Overwrite items with refreshed state
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	oplogs := respData["oplog"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	oplogs := respData.Oplog
 	if len(oplogs) == 1 {
-		oplog := oplogs[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(oplog["id"].(float64)))
-		state.Name = types.StringValue(oplog["name"].(string))
-		state.ProjectID = types.Int64Value(int64(oplog["projectId"].(float64)))
+		oplog := oplogs[0]
+		state.ID = types.Int64Value(oplog.ID)
+		state.Name = stringValueOrEmpty(oplog.Name)
+		state.ProjectID = int64ValueOrZero(oplog.ProjectID)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter oplog ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -241,6 +283,14 @@ func (r *oplogResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updateoplog = `mutation UpdateOplog ($id: bigint, $name: String, $project_id: bigint){
 		update_oplog(where: {id: {_eq: $id}}, _set: {name: $name, projectId: $project_id}) {
@@ -256,7 +306,9 @@ func (r *oplogResource) Update(ctx context.Context, req resource.UpdateRequest,
 	request.Var("id", state.ID.ValueInt64())
 	request.Var("name", plan.Name.ValueString())
 	request.Var("project_id", plan.ProjectID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateOplog mutationResponse[ghostwriterOplog] `json:"update_oplog"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Oplog",
@@ -265,13 +317,13 @@ func (r *oplogResource) Update(ctx context.Context, req resource.UpdateRequest,
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	updated_oplogs := respData["update_oplog"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_oplogs := respData.UpdateOplog.Returning
 	if len(updated_oplogs) == 1 {
-		oplog := updated_oplogs[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(oplog["id"].(float64)))
-		plan.Name = types.StringValue(oplog["name"].(string))
-		plan.ProjectID = types.Int64Value(int64(oplog["projectId"].(float64)))
+		oplog := updated_oplogs[0]
+		plan.ID = types.Int64Value(oplog.ID)
+		plan.Name = stringValueOrEmpty(oplog.Name)
+		plan.ProjectID = int64ValueOrZero(oplog.ProjectID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -287,6 +339,7 @@ func (r *oplogResource) Update(ctx context.Context, req resource.UpdateRequest,
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_oplog", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -299,10 +352,19 @@ func (r *oplogResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		return
 	}
 
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
 	if state.ForceDelete.ValueBool() {
 		// Generate API request body from plan
 		const deleteoplog = `mutation DeleteOplog ($id: bigint){
 			delete_oplog(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -310,12 +372,12 @@ func (r *oplogResource) Delete(ctx context.Context, req resource.DeleteRequest,
 		}`
 		request := graphql.NewRequest(deleteoplog)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
-			return
-		}
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Oplog", "oplog ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
 	} else {
 		tflog.Info(ctx, "Cowardly refusing to delete oplog. Oplog expiration will be managed by ghostwriter. Set force_delete to true to delete oplog.")
+	}
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_oplog", req.State.Raw, tftypes.Value{})...)
 }
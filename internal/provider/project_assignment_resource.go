@@ -0,0 +1,536 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &projectAssignmentResource{}
+	_ resource.ResourceWithConfigure      = &projectAssignmentResource{}
+	_ resource.ResourceWithModifyPlan     = &projectAssignmentResource{}
+	_ resource.ResourceWithValidateConfig = &projectAssignmentResource{}
+	_ resource.ResourceWithImportState    = &projectAssignmentResource{}
+)
+
+// NewprojectAssignmentResource is a helper function to simplify the provider implementation.
+func NewprojectAssignmentResource() resource.Resource {
+	return &projectAssignmentResource{}
+}
+
+// projectAssignmentResource is the resource implementation.
+type projectAssignmentResource struct {
+	client *ghostwriterClient
+}
+
+// projectAssignmentResourceModel maps the resource schema data.
+type projectAssignmentResourceModel struct {
+	ID          types.Int64    `tfsdk:"id"`
+	ProjectID   types.Int64    `tfsdk:"project_id"`
+	Username    types.String   `tfsdk:"username"`
+	UserID      types.Int64    `tfsdk:"user_id"`
+	Role        types.String   `tfsdk:"role"`
+	RoleID      types.Int64    `tfsdk:"role_id"`
+	StartDate   types.String   `tfsdk:"start_date"`
+	EndDate     types.String   `tfsdk:"end_date"`
+	Note        types.String   `tfsdk:"note"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *projectAssignmentResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project_assignment"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectAssignmentResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *projectAssignmentResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Assign an operator to a project in ghostwriter.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the project assignment.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the project assignment.",
+				Computed:    true,
+			},
+			"project_id": schema.Int64Attribute{
+				Description: "The unique identifier of the project the operator is assigned to.",
+				Required:    true,
+			},
+			"username": schema.StringAttribute{
+				Description: "The username of the operator to assign to the project.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"user_id": schema.Int64Attribute{
+				Description: "The unique identifier of the assigned operator.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"role": schema.StringAttribute{
+				Description: "The name of the operator's project role, e.g. Assessment Lead.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"role_id": schema.Int64Attribute{
+				Description: "The unique identifier of the operator's project role.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"start_date": schema.StringAttribute{
+				Description: "The date the operator starts working on the project. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"end_date": schema.StringAttribute{
+				Description: "The date the operator finishes working on the project. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"note": schema.StringAttribute{
+				Description: "Notes about the assignment.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks the assignment does not end before it starts.
+func (r *projectAssignmentResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config projectAssignmentResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateDateRange(config.StartDate, config.EndDate, "Project Assignment")...)
+}
+
+// ModifyPlan marks user_id and role_id unknown when the username or role they are looked up
+// from changes, as they are otherwise kept from state.
+func (r *projectAssignmentResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to compare when the resource is created or destroyed
+	if req.State.Raw.IsNull() || req.Plan.Raw.IsNull() {
+		return
+	}
+
+	var plan, state projectAssignmentResourceModel
+	resp.Diagnostics.Append(req.Plan.Get(ctx, &plan)...)
+	resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if !plan.Username.Equal(state.Username) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("user_id"), types.Int64Unknown())...)
+	}
+	if !plan.Role.Equal(state.Role) {
+		resp.Diagnostics.Append(resp.Plan.SetAttribute(ctx, path.Root("role_id"), types.Int64Unknown())...)
+	}
+}
+
+// ImportState imports the resource state from Terraform state.
+func (r *projectAssignmentResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	tflog.Debug(ctx, fmt.Sprintf("Importing project assignment resource ID: %s", req.ID))
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Parsing Import ID",
+			"Could not parse import ID: "+err.Error(),
+		)
+		return
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+}
+
+// resolveIDs looks up the user and project role IDs for the username and role in the plan.
+func (r *projectAssignmentResource) resolveIDs(ctx context.Context, plan *projectAssignmentResourceModel) diag.Diagnostics {
+	var diags diag.Diagnostics
+	user_id, err := r.client.lookupID(ctx, "user", "username", plan.Username.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("username"),
+			"Unknown Ghostwriter User",
+			"Could not find the ghostwriter user "+plan.Username.ValueString()+": "+err.Error(),
+		)
+	}
+	role_id, err := r.client.lookupID(ctx, "projectRole", "projectRole", plan.Role.ValueString())
+	if err != nil {
+		diags.AddAttributeError(
+			path.Root("role"),
+			"Unknown Ghostwriter Project Role",
+			"Could not find the ghostwriter project role "+plan.Role.ValueString()+": "+err.Error(),
+		)
+	}
+	plan.UserID = types.Int64Value(user_id)
+	plan.RoleID = types.Int64Value(role_id)
+	return diags
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectAssignmentResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.resolveIDs(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	const insertprojectassignment = `mutation InsertProjectAssignment ($project_id: bigint, $operator_id: bigint, $role_id: bigint, $start_date: date, $end_date: date, $note: String){
+		insert_projectAssignment(objects: {projectId: $project_id, operatorId: $operator_id, roleId: $role_id, startDate: $start_date, endDate: $end_date, note: $note}) {
+			returning {
+				id
+				projectId
+				operatorId
+				roleId
+				startDate
+				endDate
+				note
+				user {
+					username
+				}
+				projectRole {
+					projectRole
+				}
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Creating project assignment: %v", plan))
+	request := graphql.NewRequest(insertprojectassignment)
+	plan.setRequestVars(request)
+	var respData struct {
+		InsertProjectAssignment mutationResponse[ghostwriterProjectAssignment] `json:"insert_projectAssignment"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project assignment",
+			"Could not create project assignment, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	assignments := respData.InsertProjectAssignment.Returning
+	if len(assignments) == 1 {
+		plan.setProjectAssignment(assignments[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error creating project assignment",
+			"Could not create project assignment: Project assignment not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_project_assignment", tftypes.Value{}, resp.State.Raw)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectAssignmentResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state projectAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const queryprojectassignment = `query QueryProjectAssignment ($id: bigint){
+		projectAssignment(where: {id: {_eq: $id}}) {
+			id
+			projectId
+			operatorId
+			roleId
+			startDate
+			endDate
+			note
+			user {
+				username
+			}
+			projectRole {
+				projectRole
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Reading project assignment: %v", state.ID))
+	request := graphql.NewRequest(queryprojectassignment)
+	request.Var("id", state.ID.ValueInt64())
+	var respData struct {
+		ProjectAssignment []ghostwriterProjectAssignment `json:"projectAssignment"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Project Assignment",
+			"Could not read Ghostwriter project assignment ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	assignments := respData.ProjectAssignment
+	if len(assignments) == 1 {
+		state.setProjectAssignment(assignments[0])
+
+		// Set refreshed state
+		diags = resp.State.Set(ctx, &state)
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter project assignment ID %v not found, removing from state", state.ID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectAssignmentResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan projectAssignmentResourceModel
+	var state projectAssignmentResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(stateDiags...)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
+	resp.Diagnostics.Append(r.resolveIDs(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Generate API request body from plan
+	const updateprojectassignment = `mutation UpdateProjectAssignment ($id: bigint, $project_id: bigint, $operator_id: bigint, $role_id: bigint, $start_date: date, $end_date: date, $note: String){
+		update_projectAssignment(where: {id: {_eq: $id}}, _set: {projectId: $project_id, operatorId: $operator_id, roleId: $role_id, startDate: $start_date, endDate: $end_date, note: $note}) {
+			returning {
+				id
+				projectId
+				operatorId
+				roleId
+				startDate
+				endDate
+				note
+				user {
+					username
+				}
+				projectRole {
+					projectRole
+				}
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Updating project assignment: %v", plan))
+	request := graphql.NewRequest(updateprojectassignment)
+	request.Var("id", state.ID.ValueInt64())
+	plan.setRequestVars(request)
+	var respData struct {
+		UpdateProjectAssignment mutationResponse[ghostwriterProjectAssignment] `json:"update_projectAssignment"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Project Assignment",
+			"Could not update project assignment ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_assignments := respData.UpdateProjectAssignment.Returning
+	if len(updated_assignments) == 1 {
+		plan.setProjectAssignment(updated_assignments[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Project Assignment",
+			"Could not update project assignment ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": project assignment not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_project_assignment", req.State.Raw, resp.State.Raw)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectAssignmentResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state projectAssignmentResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const deleteprojectassignment = `mutation DeleteProjectAssignment ($id: bigint){
+		delete_projectAssignment(where: {id: {_eq: $id}}) {
+			affected_rows
+			returning {
+				id
+			}
+		}
+	}`
+	request := graphql.NewRequest(deleteprojectassignment)
+	request.Var("id", state.ID.ValueInt64())
+	resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Project Assignment", "project assignment ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "delete", "ghostwriter_project_assignment", req.State.Raw, tftypes.Value{})...)
+}
+
+// setRequestVars sets the variables shared by the insert and update project assignment mutations.
+func (m *projectAssignmentResourceModel) setRequestVars(request *graphql.Request) {
+	request.Var("project_id", m.ProjectID.ValueInt64())
+	request.Var("operator_id", m.UserID.ValueInt64())
+	request.Var("role_id", m.RoleID.ValueInt64())
+	request.Var("start_date", m.StartDate.ValueString())
+	request.Var("end_date", m.EndDate.ValueString())
+	request.Var("note", m.Note.ValueString())
+}
+
+// setProjectAssignment copies a project assignment row returned by Ghostwriter into the model.
+func (m *projectAssignmentResourceModel) setProjectAssignment(assignment ghostwriterProjectAssignment) {
+	m.ID = types.Int64Value(assignment.ID)
+	m.ProjectID = int64ValueOrZero(assignment.ProjectID)
+	m.UserID = int64ValueOrZero(assignment.OperatorID)
+	m.RoleID = int64ValueOrZero(assignment.RoleID)
+	m.StartDate = stringValueOrEmpty(assignment.StartDate)
+	m.EndDate = stringValueOrEmpty(assignment.EndDate)
+	m.Note = stringValueOrEmpty(assignment.Note)
+	if assignment.User != nil {
+		m.Username = stringValueOrEmpty(assignment.User.Username)
+	}
+	if assignment.ProjectRole != nil {
+		m.Role = stringValueOrEmpty(assignment.ProjectRole.ProjectRole)
+	}
+}
@@ -0,0 +1,117 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+	"github.com/hashicorp/terraform-plugin-testing/knownvalue"
+	"github.com/hashicorp/terraform-plugin-testing/plancheck"
+	"github.com/hashicorp/terraform-plugin-testing/tfjsonpath"
+)
+
+func TestProjectAssignmentResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+data "ghostwriter_whoami" "current" {}
+
+resource "ghostwriter_project_assignment" "test" {
+  project_id = data.ghostwriter_project.testproject.id
+  username = data.ghostwriter_whoami.current.username
+  role = "Operator"
+  start_date = "2024-01-01"
+  end_date = "2024-02-01"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_project_assignment.test", "project_id", "1"),
+					resource.TestCheckResourceAttr("ghostwriter_project_assignment.test", "role", "Operator"),
+					resource.TestCheckResourceAttrPair("ghostwriter_project_assignment.test", "username", "data.ghostwriter_whoami.current", "username"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project_assignment.test", "user_id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project_assignment.test", "role_id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project_assignment.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project_assignment.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ghostwriter_project_assignment.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+data "ghostwriter_whoami" "current" {}
+
+resource "ghostwriter_project_assignment" "test" {
+  project_id = data.ghostwriter_project.testproject.id
+  username = data.ghostwriter_whoami.current.username
+  role = "Operator"
+  start_date = "2024-01-01"
+  end_date = "2024-03-01"
+  note = "Extended by terraform"
+}
+`,
+				ConfigPlanChecks: resource.ConfigPlanChecks{
+					PreApply: []plancheck.PlanCheck{
+						plancheck.ExpectResourceAction("ghostwriter_project_assignment.test", plancheck.ResourceActionUpdate),
+						plancheck.ExpectKnownValue("ghostwriter_project_assignment.test", tfjsonpath.New("user_id"), knownvalue.NotNull()),
+						plancheck.ExpectKnownValue("ghostwriter_project_assignment.test", tfjsonpath.New("role_id"), knownvalue.NotNull()),
+					},
+				},
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_project_assignment.test", "end_date", "2024-03-01"),
+					resource.TestCheckResourceAttr("ghostwriter_project_assignment.test", "note", "Extended by terraform"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project_assignment.test", "last_updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestProjectAssignmentResourceInvalidDates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ghostwriter_project_assignment" "test" {
+  project_id = 1
+  username   = "admin"
+  role       = "Operator"
+  start_date = "2024-02-01"
+  end_date   = "2024-01-01"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Project Assignment Dates"),
+			},
+			{
+				Config: providerConfig + `
+resource "ghostwriter_project_assignment" "test" {
+  project_id = 1
+  username   = "admin"
+  role       = "Operator"
+  start_date = "2024-01-01"
+  end_date   = "x2024-02-01junk"
+}
+`,
+				ExpectError: regexp.MustCompile("Date must be in the format YYYY-MM-DD"),
+			},
+		},
+	})
+}
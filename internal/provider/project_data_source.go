@@ -23,7 +23,7 @@ func NewprojectDataSource() datasource.DataSource {
 
 // projectDataSource is the data source implementation.
 type projectDataSource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // projectType maps coffees schema data.
@@ -56,12 +56,12 @@ func (d *projectDataSource) Configure(_ context.Context, req datasource.Configur
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -163,7 +163,9 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}`
 	request := graphql.NewRequest(queryProject)
 	request.Var("name", plan.CodeName.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		Project []ghostwriterProject `json:"project"`
+	}
 	if err := d.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter project",
@@ -173,37 +175,9 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 	}
 
 	// Overwrite items with refreshed state
-	projects := respData["project"].([]interface{})
+	projects := respData.Project
 	if len(projects) == 1 {
-		project := projects[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(project["id"].(float64)))
-		state.ClientID = types.Int64Value(int64(project["clientId"].(float64)))
-		state.ProjectTypeID = types.Int64Value(int64(project["projectTypeId"].(float64)))
-		operatorId, ok := project["operatorId"].(float64)
-		if !ok || project["operatorId"] == nil {
-			state.OperatorID = types.Int64Value(0)
-		} else {
-			state.OperatorID = types.Int64Value(int64(operatorId))
-		}
-		state.CodeName = types.StringValue(project["codename"].(string))
-		state.Complete = types.BoolValue(project["complete"].(bool))
-		state.StartDate = types.StringValue(project["startDate"].(string))
-		startTime, ok := project["startTime"].(string)
-		if !ok || project["startTime"] == nil {
-			state.StartTime = types.StringValue("")
-		} else {
-			state.StartTime = types.StringValue(startTime)
-		}
-		state.EndDate = types.StringValue(project["endDate"].(string))
-		endTime, ok := project["endTime"].(string)
-		if !ok || project["endTime"] == nil {
-			state.EndTime = types.StringValue("")
-		} else {
-			state.EndTime = types.StringValue(endTime)
-		}
-		state.Timezone = types.StringValue(project["timezone"].(string))
-		state.Note = types.StringValue(project["note"].(string))
-		state.SlackChannel = types.StringValue(project["slackChannel"].(string))
+		state.setProject(projects[0])
 
 		// Set state
 		diags = resp.State.Set(ctx, &state)
@@ -218,3 +192,20 @@ func (d *projectDataSource) Read(ctx context.Context, req datasource.ReadRequest
 		return
 	}
 }
+
+// setProject copies a project row returned by Ghostwriter into the model.
+func (m *projectDataSourceModel) setProject(project ghostwriterProject) {
+	m.ID = types.Int64Value(project.ID)
+	m.ClientID = int64ValueOrZero(project.ClientID)
+	m.ProjectTypeID = int64ValueOrZero(project.ProjectTypeID)
+	m.OperatorID = int64ValueOrZero(project.OperatorID)
+	m.CodeName = stringValueOrEmpty(project.Codename)
+	m.Complete = boolValueOrFalse(project.Complete)
+	m.StartDate = stringValueOrEmpty(project.StartDate)
+	m.StartTime = stringValueOrEmpty(project.StartTime)
+	m.EndDate = stringValueOrEmpty(project.EndDate)
+	m.EndTime = stringValueOrEmpty(project.EndTime)
+	m.Timezone = stringValueOrEmpty(project.Timezone)
+	m.Note = stringValueOrEmpty(project.Note)
+	m.SlackChannel = stringValueOrEmpty(project.SlackChannel)
+}
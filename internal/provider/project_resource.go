@@ -0,0 +1,542 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ resource.Resource                   = &projectResource{}
+	_ resource.ResourceWithConfigure      = &projectResource{}
+	_ resource.ResourceWithValidateConfig = &projectResource{}
+	_ resource.ResourceWithImportState    = &projectResource{}
+)
+
+// NewprojectResource is a helper function to simplify the provider implementation.
+func NewprojectResource() resource.Resource {
+	return &projectResource{}
+}
+
+// projectResource is the resource implementation.
+type projectResource struct {
+	client *ghostwriterClient
+}
+
+// projectResourceModel maps the resource schema data. The project attributes are shared with
+// the ghostwriter_project data source.
+type projectResourceModel struct {
+	projectDataSourceModel
+	ForceDelete types.Bool     `tfsdk:"force_delete"`
+	LastUpdated types.String   `tfsdk:"last_updated"`
+	Timeouts    timeouts.Value `tfsdk:"timeouts"`
+}
+
+// Metadata returns the resource type name.
+func (r *projectResource) Metadata(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_project"
+}
+
+// Configure adds the provider configured client to the resource.
+func (r *projectResource) Configure(_ context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	r.client = client
+}
+
+// Schema defines the schema for the resource.
+func (r *projectResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Create a project in ghostwriter.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the project.",
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"last_updated": schema.StringAttribute{
+				Description: "Timestamp of the last Terraform update of the project.",
+				Computed:    true,
+			},
+			"client_id": schema.Int64Attribute{
+				Description: "The ID of the client the project is for.",
+				Required:    true,
+			},
+			"project_type_id": schema.Int64Attribute{
+				Description: "The ID of the project type",
+				Required:    true,
+			},
+			"operator_id": schema.Int64Attribute{
+				Description: "The ID of the user who created the project. Left unset when not provided.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
+			},
+			"code_name": schema.StringAttribute{
+				Description: "The project codename. A unique codename is generated by ghostwriter when not provided.",
+				Optional:    true,
+				Computed:    true,
+				PlanModifiers: []planmodifier.String{
+					stringplanmodifier.UseStateForUnknown(),
+				},
+				Validators: []validator.String{
+					stringvalidator.LengthBetween(1, 255),
+				},
+			},
+			"complete": schema.BoolAttribute{
+				Description: "If the project is complete. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+			"start_date": schema.StringAttribute{
+				Description: "The start date of the project. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"start_time": schema.StringAttribute{
+				Description: "The time work starts each day. Format: HH:MM:SS. Default is 09:00:00.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("09:00:00"),
+			},
+			"end_date": schema.StringAttribute{
+				Description: "The end date of the project. Format: YYYY-MM-DD.",
+				Required:    true,
+				Validators: []validator.String{
+					stringvalidator.RegexMatches(
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
+						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
+					),
+				},
+			},
+			"end_time": schema.StringAttribute{
+				Description: "The time work ends each day. Format: HH:MM:SS. Default is 17:00:00.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("17:00:00"),
+			},
+			"timezone": schema.StringAttribute{
+				Description: "The projects timezone, e.g. Europe/London. Default is America/Los_Angeles.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString("America/Los_Angeles"),
+			},
+			"note": schema.StringAttribute{
+				Description: "The note asociated with the project",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"slack_channel": schema.StringAttribute{
+				Description: "The projects slack channel",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(""),
+			},
+			"force_delete": schema.BoolAttribute{
+				Description: "If false, the project will not be deleted from the ghostwriter instance when not managed by terraform. If true, the project and everything recorded against it will be hard-deleted from the ghostwriter instance. Default is false.",
+				Optional:    true,
+				Computed:    true,
+				Default:     booldefault.StaticBool(false),
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ValidateConfig checks the project does not end before it starts.
+func (r *projectResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config projectResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateDateRange(config.StartDate, config.EndDate, "Project")...)
+}
+
+// ImportState imports the resource state from Terraform state by ID or codename.
+func (r *projectResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
+	// Retrieve import ID and save to id attribute
+	tflog.Debug(ctx, fmt.Sprintf("Importing project resource: %s", req.ID))
+	id, err := strconv.ParseInt(req.ID, 10, 64)
+	if err != nil {
+		const queryprojectcodename = `query QueryProject ($codename: String){
+			project(where: {codename: {_eq: $codename}}) {
+				id
+			}
+		}`
+		request := graphql.NewRequest(queryprojectcodename)
+		request.Var("codename", req.ID)
+		var respData struct {
+			Project []ghostwriterProject `json:"project"`
+		}
+		if err := r.client.Run(ctx, request, &respData); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Importing Ghostwriter Project",
+				"Could not look up project codename "+req.ID+": "+err.Error(),
+			)
+			return
+		}
+		if len(respData.Project) != 1 {
+			resp.Diagnostics.AddError(
+				"Error Importing Ghostwriter Project",
+				fmt.Sprintf("Expected one project with the ID or codename %s, found %d.", req.ID, len(respData.Project)),
+			)
+			return
+		}
+		id = respData.Project[0].ID
+	}
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("force_delete"), false)...)
+}
+
+// Create creates the resource and sets the initial Terraform state.
+func (r *projectResource) Create(ctx context.Context, req resource.CreateRequest, resp *resource.CreateResponse) {
+	var plan projectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	codename := plan.CodeName.ValueString()
+	if plan.CodeName.IsUnknown() || codename == "" {
+		const generatecodename = `query GenerateCodename {
+			generateCodename {
+				codename
+			}
+		}`
+		var codenameResp struct {
+			GenerateCodename struct {
+				Codename string `json:"codename"`
+			} `json:"generateCodename"`
+		}
+		if err := r.client.Run(ctx, graphql.NewRequest(generatecodename), &codenameResp); err != nil {
+			resp.Diagnostics.AddError(
+				"Error creating project",
+				"Could not generate a project codename, set code_name instead: "+err.Error(),
+			)
+			return
+		}
+		codename = codenameResp.GenerateCodename.Codename
+	}
+
+	// Generate API request body from plan
+	const insertproject = `mutation InsertProject ($client_id: bigint, $project_type_id: bigint, $operator_id: bigint, $codename: String, $complete: Boolean, $start_date: date, $start_time: time, $end_date: date, $end_time: time, $timezone: String, $note: String, $slack_channel: String){
+		insert_project(objects: {clientId: $client_id, projectTypeId: $project_type_id, operatorId: $operator_id, codename: $codename, complete: $complete, startDate: $start_date, startTime: $start_time, endDate: $end_date, endTime: $end_time, timezone: $timezone, note: $note, slackChannel: $slack_channel}) {
+			returning {
+				id
+				clientId
+				operatorId
+				projectTypeId
+				codename
+				complete
+				startDate
+				startTime
+				endDate
+				endTime
+				timezone
+				note
+				slackChannel
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Creating project: %v", plan))
+	request := graphql.NewRequest(insertproject)
+	request.Var("codename", codename)
+	plan.setRequestVars(request)
+	var respData struct {
+		InsertProject mutationResponse[ghostwriterProject] `json:"insert_project"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error creating project",
+			"Could not create project, unexpected error: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	projects := respData.InsertProject.Returning
+	if len(projects) == 1 {
+		plan.setProject(projects[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error creating project",
+			"Could not create project: Project not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_project", tftypes.Value{}, resp.State.Raw)...)
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (r *projectResource) Read(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
+	// Get current state
+	var state projectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const queryproject = `query QueryProject ($id: bigint){
+		project(where: {id: {_eq: $id}}) {
+			id
+			clientId
+			operatorId
+			projectTypeId
+			codename
+			complete
+			startDate
+			startTime
+			endDate
+			endTime
+			timezone
+			note
+			slackChannel
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Reading project: %v", state.ID))
+	request := graphql.NewRequest(queryproject)
+	request.Var("id", state.ID.ValueInt64())
+	var respData struct {
+		Project []ghostwriterProject `json:"project"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Project",
+			"Could not read Ghostwriter project ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	// Overwrite items with refreshed state
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	projects := respData.Project
+	if len(projects) == 1 {
+		state.setProject(projects[0])
+
+		// Set refreshed state
+		diags = resp.State.Set(ctx, &state)
+	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter project ID %v not found, removing from state", state.ID))
+		resp.State.RemoveResource(ctx)
+		return
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// Update updates the resource and sets the updated Terraform state on success.
+func (r *projectResource) Update(ctx context.Context, req resource.UpdateRequest, resp *resource.UpdateResponse) {
+	// Retrieve values from plan
+	var plan projectResourceModel
+	var state projectResourceModel
+	diags := req.Plan.Get(ctx, &plan)
+	stateDiags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(stateDiags...)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
+	// Generate API request body from plan
+	const updateproject = `mutation UpdateProject ($id: bigint, $client_id: bigint, $project_type_id: bigint, $operator_id: bigint, $codename: String, $complete: Boolean, $start_date: date, $start_time: time, $end_date: date, $end_time: time, $timezone: String, $note: String, $slack_channel: String){
+		update_project(where: {id: {_eq: $id}}, _set: {clientId: $client_id, projectTypeId: $project_type_id, operatorId: $operator_id, codename: $codename, complete: $complete, startDate: $start_date, startTime: $start_time, endDate: $end_date, endTime: $end_time, timezone: $timezone, note: $note, slackChannel: $slack_channel}) {
+			returning {
+				id
+				clientId
+				operatorId
+				projectTypeId
+				codename
+				complete
+				startDate
+				startTime
+				endDate
+				endTime
+				timezone
+				note
+				slackChannel
+			}
+		}
+	}`
+	tflog.Debug(ctx, fmt.Sprintf("Updating project: %v", plan))
+	request := graphql.NewRequest(updateproject)
+	request.Var("id", state.ID.ValueInt64())
+	request.Var("codename", plan.CodeName.ValueString())
+	plan.setRequestVars(request)
+	var respData struct {
+		UpdateProject mutationResponse[ghostwriterProject] `json:"update_project"`
+	}
+	if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Project",
+			"Could not update project ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_projects := respData.UpdateProject.Returning
+	if len(updated_projects) == 1 {
+		plan.setProject(updated_projects[0])
+		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
+
+		// Set state to fully populated data
+		diags = resp.State.Set(ctx, plan)
+	} else {
+		resp.Diagnostics.AddError(
+			"Error Updating Ghostwriter Project",
+			"Could not update project ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": project not found",
+		)
+	}
+
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_project", req.State.Raw, resp.State.Raw)...)
+}
+
+// Delete deletes the resource and removes the Terraform state on success.
+func (r *projectResource) Delete(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
+	// Retrieve values from state
+	var state projectResourceModel
+	diags := req.State.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	if state.ForceDelete.ValueBool() {
+		// Generate API request body from plan
+		const deleteproject = `mutation DeleteProject ($id: bigint){
+			delete_project(where: {id: {_eq: $id}}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		request := graphql.NewRequest(deleteproject)
+		request.Var("id", state.ID.ValueInt64())
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Project", "project ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+	} else {
+		tflog.Info(ctx, "Cowardly refusing to delete project. The project and its history will remain in ghostwriter. Set force_delete to true to delete project.")
+	}
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(r.client.audit(ctx, deleteAuditAction(state.ForceDelete), "ghostwriter_project", req.State.Raw, tftypes.Value{})...)
+}
+
+// setRequestVars sets the variables shared by the insert and update project mutations.
+func (m *projectResourceModel) setRequestVars(request *graphql.Request) {
+	request.Var("client_id", m.ClientID.ValueInt64())
+	request.Var("project_type_id", m.ProjectTypeID.ValueInt64())
+	request.Var("operator_id", nullableInt64(m.OperatorID))
+	request.Var("complete", m.Complete.ValueBool())
+	request.Var("start_date", m.StartDate.ValueString())
+	request.Var("start_time", m.StartTime.ValueString())
+	request.Var("end_date", m.EndDate.ValueString())
+	request.Var("end_time", m.EndTime.ValueString())
+	request.Var("timezone", m.Timezone.ValueString())
+	request.Var("note", m.Note.ValueString())
+	request.Var("slack_channel", m.SlackChannel.ValueString())
+}
@@ -0,0 +1,109 @@
+package provider
+
+import (
+	"regexp"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestProjectResource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+resource "ghostwriter_project" "test" {
+  client_id = data.ghostwriter_project.testproject.client_id
+  project_type_id = data.ghostwriter_project.testproject.project_type_id
+  start_date = "2024-01-01"
+  end_date = "2024-02-01"
+  force_delete = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "start_date", "2024-01-01"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "end_date", "2024-02-01"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "complete", "false"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "force_delete", "true"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project.test", "code_name"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project.test", "last_updated"),
+				),
+			},
+			// ImportState testing
+			{
+				ResourceName:            "ghostwriter_project.test",
+				ImportState:             true,
+				ImportStateVerify:       true,
+				ImportStateVerifyIgnore: []string{"force_delete", "last_updated"},
+			},
+			// Update and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_project" "testproject" {
+  code_name = "TestProject"
+}
+
+resource "ghostwriter_project" "test" {
+  client_id = data.ghostwriter_project.testproject.client_id
+  project_type_id = data.ghostwriter_project.testproject.project_type_id
+  start_date = "2024-01-01"
+  end_date = "2024-03-01"
+  note = "Extended by terraform"
+  complete = true
+  force_delete = true
+
+  timeouts {
+    update = "2m"
+  }
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "end_date", "2024-03-01"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "note", "Extended by terraform"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "complete", "true"),
+					resource.TestCheckResourceAttr("ghostwriter_project.test", "timeouts.update", "2m"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project.test", "id"),
+					resource.TestCheckResourceAttrSet("ghostwriter_project.test", "last_updated"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestProjectResourceInvalidDates(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			{
+				Config: providerConfig + `
+resource "ghostwriter_project" "test" {
+  client_id       = 1
+  project_type_id = 1
+  start_date      = "2024-02-01"
+  end_date        = "2024-01-01"
+}
+`,
+				ExpectError: regexp.MustCompile("Invalid Project Dates"),
+			},
+			{
+				Config: providerConfig + `
+resource "ghostwriter_project" "test" {
+  client_id       = 1
+  project_type_id = 1
+  start_date      = "x2024-01-01junk"
+  end_date        = "2024-02-01"
+}
+`,
+				ExpectError: regexp.MustCompile("Date must be in the format YYYY-MM-DD"),
+			},
+		},
+	})
+}
@@ -2,15 +2,21 @@ package provider
 
 import (
 	"context"
-	"crypto/tls"
+	"fmt"
 	"net/http"
+	"net/url"
 	"os"
+	"strconv"
+	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
@@ -24,9 +30,20 @@ var (
 
 // GhostwriterProviderModel maps provider schema data to a Go type.
 type ghostwriterProviderModel struct {
-	Endpoint    types.String `tfsdk:"endpoint"`
-	Apikey      types.String `tfsdk:"api_key"`
-	TlsInsecure types.Bool   `tfsdk:"tls_insecure"`
+	Endpoint     types.String `tfsdk:"endpoint"`
+	Apikey       types.String `tfsdk:"api_key"`
+	Username     types.String `tfsdk:"username"`
+	Password     types.String `tfsdk:"password"`
+	TlsInsecure  types.Bool   `tfsdk:"tls_insecure"`
+	CaCertFile   types.String `tfsdk:"ca_cert_file"`
+	CaCertPem    types.String `tfsdk:"ca_cert_pem"`
+	ClientCert   types.String `tfsdk:"client_cert"`
+	ClientKey    types.String `tfsdk:"client_key"`
+	ProxyUrl     types.String `tfsdk:"proxy_url"`
+	MaxRetries   types.Int64  `tfsdk:"max_retries"`
+	RetryWaitMin types.Int64  `tfsdk:"retry_wait_min"`
+	RetryWaitMax types.Int64  `tfsdk:"retry_wait_max"`
+	AuditOplogID types.Int64  `tfsdk:"audit_oplog_id"`
 }
 
 // New is a helper function to simplify provider server and testing implementation.
@@ -65,11 +82,84 @@ func (p *ghostwriterProvider) Schema(_ context.Context, _ provider.SchemaRequest
 				Description: "The API key for the ghostwriter API. May also be provided via the GHOSTWRITER_API_KEY environment variable.",
 				Optional:    true,
 				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.ConflictsWith(path.MatchRoot("username"), path.MatchRoot("password")),
+				},
+			},
+			"username": schema.StringAttribute{
+				Description: "The username to log in to the ghostwriter API with, as an alternative to api_key. The provider logs in again when the token expires. May also be provided via the GHOSTWRITER_USERNAME environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("password")),
+				},
+			},
+			"password": schema.StringAttribute{
+				Description: "The password to log in to the ghostwriter API with, as an alternative to api_key. May also be provided via the GHOSTWRITER_PASSWORD environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("username")),
+				},
 			},
 			"tls_insecure": schema.BoolAttribute{
 				Description: "Whether to skip TLS verification when connecting to the API endpoint. May also be provided via the GHOSTWRITER_TLS_INSECURE environment variable.",
 				Optional:    true,
 			},
+			"ca_cert_file": schema.StringAttribute{
+				Description: "Path to a PEM encoded CA bundle to trust in addition to the system certificates when connecting to the API endpoint. May also be provided via the GHOSTWRITER_CA_CERT_FILE environment variable.",
+				Optional:    true,
+			},
+			"ca_cert_pem": schema.StringAttribute{
+				Description: "PEM encoded CA certificates to trust in addition to the system certificates when connecting to the API endpoint. May also be provided via the GHOSTWRITER_CA_CERT_PEM environment variable.",
+				Optional:    true,
+			},
+			"client_cert": schema.StringAttribute{
+				Description: "PEM encoded client certificate to present to the API endpoint for mutual TLS. Requires client_key. May also be provided via the GHOSTWRITER_CLIENT_CERT environment variable.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_key")),
+				},
+			},
+			"client_key": schema.StringAttribute{
+				Description: "PEM encoded private key of the client certificate. Requires client_cert. May also be provided via the GHOSTWRITER_CLIENT_KEY environment variable.",
+				Optional:    true,
+				Sensitive:   true,
+				Validators: []validator.String{
+					stringvalidator.AlsoRequires(path.MatchRoot("client_cert")),
+				},
+			},
+			"proxy_url": schema.StringAttribute{
+				Description: "URL of an http://, https:// or socks5:// proxy to send API requests through, for example socks5://127.0.0.1:1080. Credentials may be included in the URL. When unset, the HTTP_PROXY, HTTPS_PROXY and NO_PROXY environment variables are honoured. May also be provided via the GHOSTWRITER_PROXY_URL environment variable.",
+				Optional:    true,
+			},
+			"max_retries": schema.Int64Attribute{
+				Description: "The maximum number of times a request is retried after a transient failure such as a 502 from the API. Queries are retried on any connection error, mutations only when the request was not received by the server. Default is 3. May also be provided via the GHOSTWRITER_MAX_RETRIES environment variable.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_min": schema.Int64Attribute{
+				Description: "The minimum number of seconds to wait before retrying a request. The wait doubles after every attempt. Default is 1. May also be provided via the GHOSTWRITER_RETRY_WAIT_MIN environment variable.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"retry_wait_max": schema.Int64Attribute{
+				Description: "The maximum number of seconds to wait before retrying a request, unless the API asks for longer with a Retry-After header. Default is 30. May also be provided via the GHOSTWRITER_RETRY_WAIT_MAX environment variable.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(0),
+				},
+			},
+			"audit_oplog_id": schema.Int64Attribute{
+				Description: "The ID of an oplog to record every resource the provider creates, updates or destroys in. Each change is written as an oplog entry containing the values before and after the change. May also be provided via the GHOSTWRITER_AUDIT_OPLOG_ID environment variable.",
+				Optional:    true,
+				Validators: []validator.Int64{
+					int64validator.AtLeast(1),
+				},
+			},
 		},
 	}
 }
@@ -106,6 +196,24 @@ func (p *ghostwriterProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
+	if config.Username.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("username"),
+			"Unknown Ghostwriter Username",
+			"The provider cannot create the Ghostwriter API client as there is an unknown configuration value for the Ghostwriter username. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the GHOSTWRITER_USERNAME environment variable.",
+		)
+	}
+
+	if config.Password.IsUnknown() {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Unknown Ghostwriter Password",
+			"The provider cannot create the Ghostwriter API client as there is an unknown configuration value for the Ghostwriter password. "+
+				"Either target apply the source of the value first, set the value statically in the configuration, or use the GHOSTWRITER_PASSWORD environment variable.",
+		)
+	}
+
 	var tls_insecure bool
 	if config.TlsInsecure.IsUnknown() {
 		tls_insecure = false
@@ -120,7 +228,14 @@ func (p *ghostwriterProvider) Configure(ctx context.Context, req provider.Config
 
 	endpoint := os.Getenv("GHOSTWRITER_ENDPOINT")
 	api_key := os.Getenv("GHOSTWRITER_API_KEY")
-	tls_insecure = os.Getenv("GHOSTWRITER_TLS_INSECURE") == "false"
+	username := os.Getenv("GHOSTWRITER_USERNAME")
+	password := os.Getenv("GHOSTWRITER_PASSWORD")
+	tls_insecure = os.Getenv("GHOSTWRITER_TLS_INSECURE") == "true"
+	ca_cert_file := os.Getenv("GHOSTWRITER_CA_CERT_FILE")
+	ca_cert_pem := os.Getenv("GHOSTWRITER_CA_CERT_PEM")
+	client_cert := os.Getenv("GHOSTWRITER_CLIENT_CERT")
+	client_key := os.Getenv("GHOSTWRITER_CLIENT_KEY")
+	proxy_url := os.Getenv("GHOSTWRITER_PROXY_URL")
 
 	if !config.Endpoint.IsNull() {
 		endpoint = config.Endpoint.ValueString()
@@ -130,10 +245,96 @@ func (p *ghostwriterProvider) Configure(ctx context.Context, req provider.Config
 		api_key = config.Apikey.ValueString()
 	}
 
+	if !config.Username.IsNull() {
+		username = config.Username.ValueString()
+	}
+
+	if !config.Password.IsNull() {
+		password = config.Password.ValueString()
+	}
+
 	if !config.TlsInsecure.IsNull() {
 		tls_insecure = config.TlsInsecure.ValueBool()
 	}
 
+	if !config.CaCertFile.IsNull() {
+		ca_cert_file = config.CaCertFile.ValueString()
+	}
+
+	if !config.CaCertPem.IsNull() {
+		ca_cert_pem = config.CaCertPem.ValueString()
+	}
+
+	if !config.ClientCert.IsNull() {
+		client_cert = config.ClientCert.ValueString()
+	}
+
+	if !config.ClientKey.IsNull() {
+		client_key = config.ClientKey.ValueString()
+	}
+
+	if !config.ProxyUrl.IsNull() {
+		proxy_url = config.ProxyUrl.ValueString()
+	}
+
+	max_retries := int64(defaultMaxRetries)
+	retry_wait_min := int64(defaultRetryWaitMin / time.Second)
+	retry_wait_max := int64(defaultRetryWaitMax / time.Second)
+	for _, setting := range []struct {
+		attribute string
+		env       string
+		config    types.Int64
+		value     *int64
+	}{
+		{"max_retries", "GHOSTWRITER_MAX_RETRIES", config.MaxRetries, &max_retries},
+		{"retry_wait_min", "GHOSTWRITER_RETRY_WAIT_MIN", config.RetryWaitMin, &retry_wait_min},
+		{"retry_wait_max", "GHOSTWRITER_RETRY_WAIT_MAX", config.RetryWaitMax, &retry_wait_max},
+	} {
+		if env := os.Getenv(setting.env); env != "" {
+			parsed, err := strconv.ParseInt(env, 10, 64)
+			if err != nil || parsed < 0 {
+				resp.Diagnostics.AddAttributeError(
+					path.Root(setting.attribute),
+					"Invalid Ghostwriter Retry Setting",
+					"The provider cannot create the Ghostwriter API client as the "+setting.env+" environment variable must be a non-negative whole number, got: "+env,
+				)
+				continue
+			}
+			*setting.value = parsed
+		}
+		if !setting.config.IsNull() && !setting.config.IsUnknown() {
+			*setting.value = setting.config.ValueInt64()
+		}
+	}
+
+	var audit_oplog_id int64
+	if env := os.Getenv("GHOSTWRITER_AUDIT_OPLOG_ID"); env != "" {
+		parsed, err := strconv.ParseInt(env, 10, 64)
+		if err != nil || parsed < 1 {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("audit_oplog_id"),
+				"Invalid Ghostwriter Audit Oplog",
+				"The provider cannot create the Ghostwriter API client as the GHOSTWRITER_AUDIT_OPLOG_ID environment variable must be an oplog ID, got: "+env,
+			)
+		}
+		audit_oplog_id = parsed
+	}
+	if !config.AuditOplogID.IsNull() && !config.AuditOplogID.IsUnknown() {
+		audit_oplog_id = config.AuditOplogID.ValueInt64()
+	}
+
+	if retry_wait_max < retry_wait_min {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("retry_wait_max"),
+			"Invalid Ghostwriter Retry Setting",
+			"The provider cannot create the Ghostwriter API client as retry_wait_max ("+strconv.FormatInt(retry_wait_max, 10)+"s) is less than retry_wait_min ("+strconv.FormatInt(retry_wait_min, 10)+"s).",
+		)
+	}
+
+	// Credentials set in the configuration take precedence over the
+	// environment, so a configured username wins over GHOSTWRITER_API_KEY.
+	use_login := api_key == "" || (config.Apikey.IsNull() && !config.Username.IsNull())
+
 	// If any of the expected configurations are missing, return
 	// errors with provider-specific guidance.
 
@@ -147,44 +348,117 @@ func (p *ghostwriterProvider) Configure(ctx context.Context, req provider.Config
 		)
 	}
 
-	if api_key == "" {
+	if use_login && username == "" {
 		resp.Diagnostics.AddAttributeError(
 			path.Root("api_key"),
 			"Missing Ghostwriter API Key",
 			"The provider cannot create the Ghostwriter API client as there is a missing or empty value for the Ghostwriter API key. "+
-				"Set the api key value in the configuration or use the GHOSTWRITER_API_KEY environment variable. "+
+				"Set the api key value in the configuration or use the GHOSTWRITER_API_KEY environment variable, "+
+				"or set username and password (GHOSTWRITER_USERNAME and GHOSTWRITER_PASSWORD) to log in instead. "+
+				"If either is already set, ensure the value is not empty.",
+		)
+	}
+
+	if use_login && username != "" && password == "" {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("password"),
+			"Missing Ghostwriter Password",
+			"The provider cannot create the Ghostwriter API client as there is a missing or empty value for the Ghostwriter password. "+
+				"Set the password value in the configuration or use the GHOSTWRITER_PASSWORD environment variable. "+
 				"If either is already set, ensure the value is not empty.",
 		)
 	}
 
+	tlsConfig, err := buildTLSConfig(tlsSettings{
+		insecure:   tls_insecure,
+		caCertFile: ca_cert_file,
+		caCertPEM:  ca_cert_pem,
+		clientCert: client_cert,
+		clientKey:  client_key,
+	})
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Invalid Ghostwriter TLS Configuration",
+			"The provider cannot create the Ghostwriter API client as the TLS settings are invalid: "+err.Error(),
+		)
+	}
+
+	var proxy *url.URL
+	if proxy_url != "" {
+		proxy, err = parseProxyURL(proxy_url)
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("proxy_url"),
+				"Invalid Ghostwriter Proxy URL",
+				"The provider cannot create the Ghostwriter API client as the proxy URL is invalid: "+err.Error(),
+			)
+		}
+	}
+
 	if resp.Diagnostics.HasError() {
 		return
 	}
 
 	ctx = tflog.SetField(ctx, "ghostwriter_endpoint", endpoint)
 	ctx = tflog.SetField(ctx, "ghostwriter_api_key", api_key)
+	ctx = tflog.SetField(ctx, "ghostwriter_username", username)
+	ctx = tflog.SetField(ctx, "ghostwriter_password", password)
 	ctx = tflog.SetField(ctx, "ghostwriter_tls_insecure", tls_insecure)
-	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ghostwriter_api_key")
+	ctx = tflog.SetField(ctx, "ghostwriter_ca_cert_file", ca_cert_file)
+	ctx = tflog.SetField(ctx, "ghostwriter_client_certificate", client_cert != "")
+	ctx = tflog.SetField(ctx, "ghostwriter_max_retries", max_retries)
+	if proxy != nil {
+		ctx = tflog.SetField(ctx, "ghostwriter_proxy_url", proxy.Redacted())
+		if proxy_password, ok := proxy.User.Password(); ok {
+			ctx = tflog.MaskAllFieldValuesStrings(ctx, proxy_password)
+			ctx = tflog.MaskMessageStrings(ctx, proxy_password)
+		}
+	}
+	ctx = tflog.MaskFieldValuesWithFieldKeys(ctx, "ghostwriter_api_key", "ghostwriter_password")
 
 	tflog.Debug(ctx, "Creating Ghostwriter graphql client")
 
 	// Create a new Ghostwriter client using the configuration values
-	var httpClient *http.Client
-	var httpctx context.Context
-	if tls_insecure {
-		tr := &http.Transport{
-			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
-		}
-		httpClient = &http.Client{Transport: tr}
-		httpctx = context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+	tr := http.DefaultTransport.(*http.Transport).Clone()
+	tr.TLSClientConfig = tlsConfig
+	if proxy != nil {
+		tr.Proxy = http.ProxyURL(proxy)
+	}
+	httpClient := &http.Client{
+		Transport: newRetryTransport(tr, int(max_retries), time.Duration(retry_wait_min)*time.Second, time.Duration(retry_wait_max)*time.Second),
+	}
+	if use_login {
+		tflog.Debug(ctx, "Authenticating to Ghostwriter with the login mutation")
+		src := newLoginTokenSource(graphql.NewClient(endpoint, graphql.WithHTTPClient(httpClient)), username, password)
+		httpClient = &http.Client{Transport: newLoginTransport(src, httpClient.Transport)}
 	} else {
-		httpctx = context.Background()
+		httpctx := context.WithValue(context.Background(), oauth2.HTTPClient, httpClient)
+		src := oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: api_key},
+		)
+		httpClient = oauth2.NewClient(httpctx, src)
+	}
+	client := newGhostwriterClient(graphql.NewClient(endpoint, graphql.WithHTTPClient(httpClient)))
+
+	credential_attribute := "api_key"
+	if use_login {
+		credential_attribute = "username"
+	}
+	whoami, diags := validateConnection(ctx, client, credential_attribute)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	tflog.Debug(ctx, fmt.Sprintf("Authenticated to Ghostwriter as %s", responseString(whoami)))
+	client.username = stringValueOrEmpty(whoami.Username).ValueString()
+
+	if audit_oplog_id != 0 {
+		resp.Diagnostics.Append(validateAuditOplog(ctx, client, audit_oplog_id)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+		client.auditOplogID = audit_oplog_id
 	}
-	src := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: api_key},
-	)
-	httpClient = oauth2.NewClient(httpctx, src)
-	client := graphql.NewClient(endpoint, graphql.WithHTTPClient(httpClient))
 
 	// Make the Ghostwriter client available during DataSource and Resource
 	// type Configure methods.
@@ -201,6 +475,11 @@ func (p *ghostwriterProvider) DataSources(_ context.Context) []func() datasource
 		NewserverproviderDataSource,
 		NewserverroleDataSource,
 		NewprojectDataSource,
+		NewwhoamiDataSource,
+		NewdomainDataSource,
+		NewdomainsDataSource,
+		NewstaticServerDataSource,
+		NewstaticServersDataSource,
 	}
 }
 
@@ -209,10 +488,15 @@ func (p *ghostwriterProvider) Resources(_ context.Context) []func() resource.Res
 	return []func() resource.Resource{
 		NewdomainResource,
 		NewdomainCheckoutResource,
+		NewdomainAllocationResource,
 		NewstaticserverCheckoutResource,
 		NewstaticserverResource,
 		NewcloudserverResource,
 		NewoplogResource,
 		NewdomainserverResource,
+		NewclientResource,
+		NewprojectResource,
+		NewprojectAssignmentResource,
+		NewoplogEntryResource,
 	}
 }
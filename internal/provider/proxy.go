@@ -0,0 +1,30 @@
+package provider
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+)
+
+// parseProxyURL parses the proxy_url setting. Only http, https and socks5 proxies are supported
+// by net/http, so any other scheme is rejected rather than silently ignored.
+func parseProxyURL(raw string) (*url.URL, error) {
+	proxy, err := url.Parse(raw)
+	if err != nil {
+		// url.Error repeats the raw URL, which may contain the proxy password
+		var url_err *url.Error
+		if errors.As(err, &url_err) {
+			err = url_err.Err
+		}
+		return nil, fmt.Errorf("parsing proxy_url: %w", err)
+	}
+	switch proxy.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, fmt.Errorf("proxy_url scheme must be one of http, https or socks5, got %q", proxy.Scheme)
+	}
+	if proxy.Host == "" {
+		return nil, fmt.Errorf("proxy_url %s does not contain a host", proxy.Redacted())
+	}
+	return proxy, nil
+}
@@ -0,0 +1,54 @@
+package provider
+
+import (
+	"context"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// nameReference describes a pair of resource attributes that refer to a row of a Ghostwriter
+// lookup table either by ID or by name.
+type nameReference struct {
+	idAttribute   string
+	nameAttribute string
+	table         string
+	column        string
+}
+
+var (
+	activityTypeReference   = nameReference{"activity_type_id", "activity_type", "activityType", "activity"}
+	serverRoleReference     = nameReference{"server_role_id", "server_role", "serverRole", "serverRole"}
+	serverProviderReference = nameReference{"server_provider_id", "server_provider", "serverProvider", "serverProvider"}
+)
+
+// resolveReferences sets the ID attribute of every reference configured by name in the plan, so
+// the resolved ID is shown at plan time and the resource only ever sends IDs to Ghostwriter.
+// Names that are not known until apply are left for the next plan.
+func (c *ghostwriterClient) resolveReferences(ctx context.Context, plan *tfsdk.Plan, references ...nameReference) diag.Diagnostics {
+	var diags diag.Diagnostics
+	for _, reference := range references {
+		var name types.String
+		diags.Append(plan.GetAttribute(ctx, path.Root(reference.nameAttribute), &name)...)
+		if diags.HasError() {
+			return diags
+		}
+		if name.IsNull() || name.IsUnknown() {
+			continue
+		}
+
+		id, err := c.lookupID(ctx, reference.table, reference.column, name.ValueString())
+		if err != nil {
+			diags.AddAttributeError(
+				path.Root(reference.nameAttribute),
+				"Error Resolving Ghostwriter Reference",
+				"Could not resolve "+reference.nameAttribute+" "+name.String()+": "+err.Error(),
+			)
+			continue
+		}
+		diags.Append(plan.SetAttribute(ctx, path.Root(reference.idAttribute), id)...)
+	}
+	return diags
+}
@@ -0,0 +1,143 @@
+package provider
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/go-retryablehttp"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+const (
+	// defaultMaxRetries is the number of times a failed request is retried when max_retries is not set.
+	defaultMaxRetries = 3
+	// defaultRetryWaitMin is the initial backoff when retry_wait_min is not set.
+	defaultRetryWaitMin = 1 * time.Second
+	// defaultRetryWaitMax is the maximum backoff when retry_wait_max is not set.
+	defaultRetryWaitMax = 30 * time.Second
+)
+
+// retryTransport is an http.RoundTripper that retries transient Ghostwriter and Hasura failures
+// with exponential backoff. GraphQL queries are retried on any connection error or transient
+// status code, whereas mutations are only retried when the request is known not to have been
+// processed, so that actions such as checkoutDomain are never run twice.
+type retryTransport struct {
+	next       http.RoundTripper
+	maxRetries int
+	waitMin    time.Duration
+	waitMax    time.Duration
+}
+
+// newRetryTransport wraps next with a retryTransport.
+func newRetryTransport(next http.RoundTripper, maxRetries int, waitMin time.Duration, waitMax time.Duration) *retryTransport {
+	if next == nil {
+		next = http.DefaultTransport
+	}
+	return &retryTransport{
+		next:       next,
+		maxRetries: maxRetries,
+		waitMin:    waitMin,
+		waitMax:    waitMax,
+	}
+}
+
+// RoundTrip sends the request, retrying it while the failure is transient and retries remain.
+func (t *retryTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	body, err := readRequestBody(req)
+	if err != nil {
+		return nil, err
+	}
+	idempotent := isGraphQLQuery(body)
+
+	for attempt := 0; ; attempt++ {
+		attemptReq := req.Clone(req.Context())
+		if body != nil {
+			attemptReq.Body = io.NopCloser(bytes.NewReader(body))
+			attemptReq.ContentLength = int64(len(body))
+		}
+
+		resp, err := t.next.RoundTrip(attemptReq)
+		if attempt >= t.maxRetries || !shouldRetry(idempotent, resp, err) {
+			return resp, err
+		}
+
+		wait := retryablehttp.DefaultBackoff(t.waitMin, t.waitMax, attempt, resp)
+		var reason string
+		if err != nil {
+			reason = err.Error()
+		} else {
+			reason = resp.Status
+			_, _ = io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
+		tflog.Warn(req.Context(), fmt.Sprintf("Ghostwriter request failed (%s), retrying in %s", reason, wait), map[string]any{
+			"attempt":     attempt + 1,
+			"max_retries": t.maxRetries,
+		})
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-req.Context().Done():
+			timer.Stop()
+			return nil, req.Context().Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// readRequestBody returns the request body so it can be replayed on every attempt.
+func readRequestBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil || req.Body == http.NoBody {
+		return nil, nil
+	}
+	defer req.Body.Close()
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, fmt.Errorf("reading request body: %w", err)
+	}
+	return body, nil
+}
+
+// isGraphQLQuery reports whether the JSON encoded GraphQL request body is a query, which is
+// safe to send more than once. Mutations and bodies that cannot be parsed are treated as
+// non-idempotent.
+func isGraphQLQuery(body []byte) bool {
+	var request struct {
+		Query string `json:"query"`
+	}
+	if err := json.Unmarshal(body, &request); err != nil {
+		return false
+	}
+	document := strings.TrimSpace(request.Query)
+	return strings.HasPrefix(document, "query") || strings.HasPrefix(document, "{")
+}
+
+// shouldRetry decides whether a failed attempt may be sent again.
+func shouldRetry(idempotent bool, resp *http.Response, err error) bool {
+	if err != nil {
+		// The connection was never established, so the server cannot have
+		// processed the request.
+		var opErr *net.OpError
+		if errors.As(err, &opErr) && opErr.Op == "dial" {
+			return true
+		}
+		return idempotent && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests:
+		// Rate limited requests are rejected before they are processed.
+		return true
+	case http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return idempotent
+	}
+	return false
+}
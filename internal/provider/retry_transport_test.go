@@ -0,0 +1,87 @@
+package provider
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	tests := []struct {
+		name         string
+		query        string
+		statuses     []int
+		header       http.Header
+		wantStatus   int
+		wantAttempts int32
+	}{
+		{
+			name:         "query retried after bad gateway",
+			query:        `query Domain { domain { id } }`,
+			statuses:     []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 3,
+		},
+		{
+			name:         "query gives up after max retries",
+			query:        `query Domain { domain { id } }`,
+			statuses:     []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable, http.StatusServiceUnavailable},
+			wantStatus:   http.StatusServiceUnavailable,
+			wantAttempts: 3,
+		},
+		{
+			name:         "mutation not retried after bad gateway",
+			query:        `mutation checkoutDomain { checkoutDomain { result } }`,
+			statuses:     []int{http.StatusBadGateway, http.StatusOK},
+			wantStatus:   http.StatusBadGateway,
+			wantAttempts: 1,
+		},
+		{
+			name:         "mutation retried after rate limit",
+			query:        `mutation checkoutDomain { checkoutDomain { result } }`,
+			statuses:     []int{http.StatusTooManyRequests, http.StatusOK},
+			header:       http.Header{"Retry-After": []string{"0"}},
+			wantStatus:   http.StatusOK,
+			wantAttempts: 2,
+		},
+		{
+			name:         "client errors not retried",
+			query:        `query Domain { domain { id } }`,
+			statuses:     []int{http.StatusBadRequest, http.StatusOK},
+			wantStatus:   http.StatusBadRequest,
+			wantAttempts: 1,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				attempt := atomic.AddInt32(&attempts, 1)
+				for key, values := range test.header {
+					w.Header()[key] = values
+				}
+				w.WriteHeader(test.statuses[attempt-1])
+			}))
+			defer server.Close()
+
+			client := &http.Client{Transport: newRetryTransport(nil, 2, time.Millisecond, 10*time.Millisecond)}
+			body := `{"query": "` + test.query + `"}`
+			resp, err := client.Post(server.URL, "application/json", strings.NewReader(body))
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != test.wantStatus {
+				t.Errorf("expected status %d, got %d", test.wantStatus, resp.StatusCode)
+			}
+			if got := atomic.LoadInt32(&attempts); got != test.wantAttempts {
+				t.Errorf("expected %d attempts, got %d", test.wantAttempts, got)
+			}
+		})
+	}
+}
@@ -24,7 +24,7 @@ func NewserverproviderDataSource() datasource.DataSource {
 
 // serverproviderDataSource is the data source implementation.
 type serverproviderDataSource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // activityType maps coffees schema data.
@@ -46,12 +46,12 @@ func (d *serverproviderDataSource) Configure(_ context.Context, req datasource.C
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,7 +99,9 @@ func (d *serverproviderDataSource) Read(ctx context.Context, req datasource.Read
 	tflog.Debug(ctx, fmt.Sprintf("Querying server providers: %v", plan))
 	request := graphql.NewRequest(queryServerProvider)
 	request.Var("name", plan.Name.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		ServerProvider []ghostwriterServerProvider `json:"serverProvider"`
+	}
 	if err := d.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter server providers",
@@ -108,13 +110,13 @@ func (d *serverproviderDataSource) Read(ctx context.Context, req datasource.Read
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
 	// Overwrite items with refreshed state
-	server_providers := respData["serverProvider"].([]interface{})
+	server_providers := respData.ServerProvider
 	if len(server_providers) == 1 {
-		server_provider := server_providers[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(server_provider["id"].(float64)))
-		state.Name = types.StringValue(server_provider["serverProvider"].(string))
+		server_provider := server_providers[0]
+		state.ID = types.Int64Value(server_provider.ID)
+		state.Name = stringValueOrEmpty(server_provider.ServerProvider)
 
 		// Set state
 		diags = resp.State.Set(ctx, &state)
@@ -24,7 +24,7 @@ func NewserverroleDataSource() datasource.DataSource {
 
 // serverroleDataSource is the data source implementation.
 type serverroleDataSource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // activityType maps coffees schema data.
@@ -46,12 +46,12 @@ func (d *serverroleDataSource) Configure(_ context.Context, req datasource.Confi
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -99,7 +99,9 @@ func (d *serverroleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 	tflog.Debug(ctx, fmt.Sprintf("Querying server roles: %v", plan))
 	request := graphql.NewRequest(queryServerRoles)
 	request.Var("name", plan.Name.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		ServerRole []ghostwriterServerRole `json:"serverRole"`
+	}
 	if err := d.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter server roles",
@@ -108,13 +110,13 @@ func (d *serverroleDataSource) Read(ctx context.Context, req datasource.ReadRequ
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
 	// Overwrite items with refreshed state
-	serverRoles := respData["serverRole"].([]interface{})
+	serverRoles := respData.ServerRole
 	if len(serverRoles) == 1 {
-		serverRole := serverRoles[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(serverRole["id"].(float64)))
-		state.Name = types.StringValue(serverRole["serverRole"].(string))
+		serverRole := serverRoles[0]
+		state.ID = types.Int64Value(serverRole.ID)
+		state.Name = stringValueOrEmpty(serverRole.ServerRole)
 
 		// Set state
 		diags = resp.State.Set(ctx, &state)
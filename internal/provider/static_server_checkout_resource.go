@@ -5,25 +5,33 @@ import (
 	"fmt"
 	"regexp"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
 
 // Ensure the implementation satisfies the expected interfaces.
 var (
-	_ resource.Resource                = &staticserverCheckoutResource{}
-	_ resource.ResourceWithConfigure   = &staticserverCheckoutResource{}
-	_ resource.ResourceWithImportState = &staticserverCheckoutResource{}
+	_ resource.Resource                   = &staticserverCheckoutResource{}
+	_ resource.ResourceWithConfigure      = &staticserverCheckoutResource{}
+	_ resource.ResourceWithModifyPlan     = &staticserverCheckoutResource{}
+	_ resource.ResourceWithValidateConfig = &staticserverCheckoutResource{}
+	_ resource.ResourceWithImportState    = &staticserverCheckoutResource{}
 )
 
 // NewstaticserverCheckoutResource is a helper function to simplify the provider implementation.
@@ -33,21 +41,27 @@ func NewstaticserverCheckoutResource() resource.Resource {
 
 // staticserverCheckoutResource is the resource implementation.
 type staticserverCheckoutResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type staticserverCheckoutResourceModel struct {
-	ID             types.Int64  `tfsdk:"id"`
-	ActivityTypeId types.Int64  `tfsdk:"activity_type_id"`
-	ServerRoleId   types.Int64  `tfsdk:"server_role_id"`
-	ServerId       types.Int64  `tfsdk:"server_id"`
-	ProjectId      types.Int64  `tfsdk:"project_id"`
-	Note           types.String `tfsdk:"note"`
-	StartDate      types.String `tfsdk:"start_date"`
-	EndDate        types.String `tfsdk:"end_date"`
-	ForceDelete    types.Bool   `tfsdk:"force_delete"`
-	LastUpdated    types.String `tfsdk:"last_updated"`
+	ID              types.Int64    `tfsdk:"id"`
+	ActivityTypeId  types.Int64    `tfsdk:"activity_type_id"`
+	ActivityType    types.String   `tfsdk:"activity_type"`
+	ServerRoleId    types.Int64    `tfsdk:"server_role_id"`
+	ServerRole      types.String   `tfsdk:"server_role"`
+	ServerId        types.Int64    `tfsdk:"server_id"`
+	ProjectId       types.Int64    `tfsdk:"project_id"`
+	Note            types.String   `tfsdk:"note"`
+	StartDate       types.String   `tfsdk:"start_date"`
+	EndDate         types.String   `tfsdk:"end_date"`
+	ForceDelete     types.Bool     `tfsdk:"force_delete"`
+	ReleaseStatus   types.String   `tfsdk:"release_status"`
+	OnDestroy       types.String   `tfsdk:"on_destroy"`
+	BurnExplanation types.String   `tfsdk:"burn_explanation"`
+	LastUpdated     types.String   `tfsdk:"last_updated"`
+	Timeouts        timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -63,12 +77,12 @@ func (r *staticserverCheckoutResource) Configure(_ context.Context, req resource
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -78,13 +92,16 @@ func (r *staticserverCheckoutResource) Configure(_ context.Context, req resource
 }
 
 // Schema defines the schema for the resource.
-func (r *staticserverCheckoutResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *staticserverCheckoutResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Checkout an existing server in ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the server checkout.",
@@ -93,10 +110,16 @@ func (r *staticserverCheckoutResource) Schema(_ context.Context, _ resource.Sche
 			"project_id": schema.Int64Attribute{
 				Description: "The unique identifier of the project the server should be checked out to.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"server_id": schema.Int64Attribute{
 				Description: "The unique identifier of the server to be checked out.",
 				Required:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.RequiresReplace(),
+				},
 			},
 			"start_date": schema.StringAttribute{
 				Description: "The start date of the project. Format: YYYY-MM-DD.",
@@ -104,7 +127,7 @@ func (r *staticserverCheckoutResource) Schema(_ context.Context, _ resource.Sche
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 256),
 					stringvalidator.RegexMatches(
-						regexp.MustCompile(`\d{4}-\d{2}-\d{2}`),
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
 						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
 					),
 				},
@@ -115,18 +138,40 @@ func (r *staticserverCheckoutResource) Schema(_ context.Context, _ resource.Sche
 				Validators: []validator.String{
 					stringvalidator.LengthBetween(1, 256),
 					stringvalidator.RegexMatches(
-						regexp.MustCompile(`\d{4}-\d{2}-\d{2}`),
+						regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`),
 						"Date must be in the format YYYY-MM-DD. e.g. 2022-01-01",
 					),
 				},
 			},
 			"activity_type_id": schema.Int64Attribute{
-				Description: "The unique identifier of the activity type being performed.",
-				Required:    true,
+				Description: "The unique identifier of the activity type being performed. Exactly one of activity_type_id or activity_type must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("activity_type")),
+				},
+			},
+			"activity_type": schema.StringAttribute{
+				Description: "The name of the activity type being performed, resolved to activity_type_id at plan time. e.g. Command and Control",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"server_role_id": schema.Int64Attribute{
-				Description: "The role of the server.",
-				Required:    true,
+				Description: "The role of the server. Exactly one of server_role_id or server_role must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("server_role")),
+				},
+			},
+			"server_role": schema.StringAttribute{
+				Description: "The name of the role of the server, resolved to server_role_id at plan time. e.g. Team Server / C2 Server",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"note": schema.StringAttribute{
 				Description: "Project-related notes, such as how the server will be used/how it worked out.",
@@ -143,10 +188,82 @@ func (r *staticserverCheckoutResource) Schema(_ context.Context, _ resource.Sche
 				Computed:    true,
 				Default:     booldefault.StaticBool(false),
 			},
+			"release_status": schema.StringAttribute{
+				Description: "The name of the server status the server is set to when the checkout is destroyed. Default is Available.",
+				Optional:    true,
+				Computed:    true,
+				Default:     stringdefault.StaticString(defaultReleaseStatus),
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"on_destroy": schema.StringAttribute{
+				Description: "What happens when the checkout is destroyed. release sets the server to release_status and keeps the checkout record, burn appends burn_explanation to the note of the checkout record, keeps the record and sets the server to Unavailable (servers have no burned status in Ghostwriter, so the explanation is only recorded in the note), expire_now ends the checkout today and releases the server, and delete deletes the checkout record and releases the server. Defaults to delete when force_delete is true, and release otherwise.",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.OneOf(onDestroyModes...),
+					stringvalidator.ConflictsWith(path.MatchRoot("force_delete")),
+				},
+			},
+			"burn_explanation": schema.StringAttribute{
+				Description: "Why the server was burned, appended to the checkout note as \"Burned: <burn_explanation>\". Required when on_destroy is burn.",
+				Optional:    true,
+			},
+		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
 		},
 	}
 }
 
+// ValidateConfig checks the checkout dates are real dates in the right order and that burned
+// checkouts explain why.
+func (r *staticserverCheckoutResource) ValidateConfig(ctx context.Context, req resource.ValidateConfigRequest, resp *resource.ValidateConfigResponse) {
+	var config staticserverCheckoutResourceModel
+	resp.Diagnostics.Append(req.Config.Get(ctx, &config)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	resp.Diagnostics.Append(validateDateRange(config.StartDate, config.EndDate, "Checkout")...)
+	resp.Diagnostics.Append(validateOnDestroy(config.OnDestroy, config.BurnExplanation)...)
+}
+
+// ModifyPlan resolves the lookup table references configured by name and checks the checkout
+// against Ghostwriter, so problems are reported by terraform plan rather than by the apply.
+func (r *staticserverCheckoutResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
+	}
+	resp.Diagnostics.Append(r.client.resolveReferences(ctx, &resp.Plan, activityTypeReference, serverRoleReference)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	var plan staticserverCheckoutResourceModel
+	resp.Diagnostics.Append(resp.Plan.Get(ctx, &plan)...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	// Only check checkouts that are being created or changed, as existing checkouts may have been
+	// valid when they were made
+	var state staticserverCheckoutResourceModel
+	if !req.State.Raw.IsNull() {
+		resp.Diagnostics.Append(req.State.Get(ctx, &state)...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	}
+	if req.State.Raw.IsNull() || !plan.ProjectId.Equal(state.ProjectId) || !plan.EndDate.Equal(state.EndDate) {
+		resp.Diagnostics.Append(r.client.checkCheckoutProject(ctx, plan.ProjectId, plan.EndDate)...)
+	}
+}
+
 // ImportState imports the resource state from Terraform state.
 func (r *staticserverCheckoutResource) ImportState(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	// Retrieve import ID and save to id attribute
@@ -162,6 +279,7 @@ func (r *staticserverCheckoutResource) ImportState(ctx context.Context, req reso
 	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("id"), id)...)
 	force_delete := types.BoolValue(false)
 	resp.Diagnostics.Append(resp.State.GetAttribute(ctx, path.Root("force_delete"), &force_delete)...)
+	resp.Diagnostics.Append(resp.State.SetAttribute(ctx, path.Root("release_status"), defaultReleaseStatus)...)
 }
 
 // Create creates the resource and sets the initial Terraform state.
@@ -173,6 +291,33 @@ func (r *staticserverCheckoutResource) Create(ctx context.Context, req resource.
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
+	// Record the checkouts that already match, so the one created below can be told apart from
+	// older checkouts with the same attributes
+	match := newCheckoutMatch("serverCheckout", map[string]any{
+		"serverId":       plan.ServerId.ValueInt64(),
+		"projectId":      plan.ProjectId.ValueInt64(),
+		"activityTypeId": plan.ActivityTypeId.ValueInt64(),
+		"serverRoleId":   plan.ServerRoleId.ValueInt64(),
+		"startDate":      plan.StartDate.ValueString(),
+		"endDate":        plan.EndDate.ValueString(),
+		"note":           plan.Note.ValueString(),
+	})
+	if err := match.recordExisting(ctx, r.client); err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Server Checkouts",
+			"Could not read the existing checkouts of server ID "+strconv.FormatInt(plan.ServerId.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
+	}
+
 	// Generate API request body from plan
 	const checkoutserver = `mutation checkoutServer ($activity_type_id: Int!, $server_id: Int!, $project_id: Int!, $note: String, $start_date: date!, $end_date: date!, $server_role_id: Int!) {
 		checkoutServer(activityTypeId: $activity_type_id, serverId: $server_id, projectId: $project_id, note: $note, startDate: $start_date, endDate: $end_date, serverRoleId: $server_role_id) {
@@ -188,7 +333,9 @@ func (r *staticserverCheckoutResource) Create(ctx context.Context, req resource.
 	request.Var("start_date", plan.StartDate.ValueString())
 	request.Var("end_date", plan.EndDate.ValueString())
 	request.Var("server_role_id", plan.ServerRoleId.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		CheckoutServer checkoutResponse `json:"checkoutServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error checking out server",
@@ -197,48 +344,62 @@ func (r *staticserverCheckoutResource) Create(ctx context.Context, req resource.
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+
+	// Older Ghostwriter instances do not return the checkout, so the new matching one is used
+	checkout_id, ok := respData.CheckoutServer.checkoutID()
+	if !ok {
+		var err error
+		checkout_id, err = match.createdID(ctx, r.client)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Reading Ghostwriter Server Checkouts",
+				"The server ID "+strconv.FormatInt(plan.ServerId.ValueInt64(), 10)+" was checked out, but the checkout could not be identified: "+err.Error()+". "+
+					"Import the checkout that was created with terraform import.",
+			)
+			return
+		}
+	}
 
-	// Run another query to get the ID of the server checkout
 	const queryservercheckout = `query QueryServerCheckout ($id: bigint){
-		serverCheckout(where: {server: {id: {_eq: $id}}}, order_by: {id: desc}) {
+		serverCheckout(where: {id: {_eq: $id}}) {
 			id
 			serverId
 			endDate
 			note
 			projectId
 			startDate
-			activityType {
-			  id
-			}
+			activityTypeId
 			serverRoleId
 		}
 	}`
-	tflog.Debug(ctx, fmt.Sprintf("Query server checkout ID: %v", plan.ServerId.ValueInt64()))
+	tflog.Debug(ctx, fmt.Sprintf("Query server checkout ID: %v", checkout_id))
 	getid_request := graphql.NewRequest(queryservercheckout)
-	getid_request.Var("id", plan.ServerId.ValueInt64())
-	var getidResp map[string]interface{}
+	getid_request.Var("id", checkout_id)
+	var getidResp struct {
+		ServerCheckout []ghostwriterServerCheckout `json:"serverCheckout"`
+	}
 	if err := r.client.Run(ctx, getid_request, &getidResp); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter Server Checkouts",
-			"Could not read Ghostwriter server checkout ID "+strconv.FormatInt(plan.ID.ValueInt64(), 10)+": "+err.Error(),
+			"The server was checked out but the checkout could not be read back: "+err.Error(),
 		)
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Server checkout response: %v", respData))
-
-	server_checkouts := getidResp["serverCheckout"].([]interface{})
-	if len(server_checkouts) >= 1 {
-		latest_checkout := server_checkouts[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(latest_checkout["id"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(latest_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		plan.ServerId = types.Int64Value(int64(latest_checkout["serverId"].(float64)))
-		plan.ProjectId = types.Int64Value(int64(latest_checkout["projectId"].(float64)))
-		plan.Note = types.StringValue(latest_checkout["note"].(string))
-		plan.StartDate = types.StringValue(latest_checkout["startDate"].(string))
-		plan.EndDate = types.StringValue(latest_checkout["endDate"].(string))
-		plan.ServerRoleId = types.Int64Value(int64(latest_checkout["serverRoleId"].(float64)))
+	tflog.Debug(ctx, fmt.Sprintf("Server checkout response: %s", responseString(getidResp)))
+
+	server_checkouts := getidResp.ServerCheckout
+	if len(server_checkouts) == 1 {
+		latest_checkout := server_checkouts[0]
+		plan.ID = types.Int64Value(latest_checkout.ID)
+		plan.ActivityTypeId = int64ValueOrZero(latest_checkout.ActivityTypeID)
+		plan.ServerId = int64ValueOrZero(latest_checkout.ServerID)
+		plan.ProjectId = int64ValueOrZero(latest_checkout.ProjectID)
+		plan.Note = stringValueOrEmpty(latest_checkout.Note)
+		plan.StartDate = stringValueOrEmpty(latest_checkout.StartDate)
+		plan.EndDate = stringValueOrEmpty(latest_checkout.EndDate)
+		plan.ServerRoleId = int64ValueOrZero(latest_checkout.ServerRoleID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -246,13 +407,14 @@ func (r *staticserverCheckoutResource) Create(ctx context.Context, req resource.
 	} else {
 		resp.Diagnostics.AddError(
 			"Error Reading Ghostwriter Server Checkouts",
-			"Could not obtain any checkouts for the server ID "+strconv.FormatInt(plan.ServerId.ValueInt64(), 10),
+			"The server ID "+strconv.FormatInt(plan.ServerId.ValueInt64(), 10)+" was checked out, but checkout ID "+strconv.FormatInt(checkout_id, 10)+" could not be found.",
 		)
 	}
 	resp.Diagnostics.Append(diags...)
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_static_server_checkout", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -265,6 +427,14 @@ func (r *staticserverCheckoutResource) Read(ctx context.Context, req resource.Re
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const queryservercheckout = `query QueryServerCheckout ($id: bigint){
 		serverCheckout(where: {id: {_eq: $id}}) {
@@ -274,40 +444,42 @@ func (r *staticserverCheckoutResource) Read(ctx context.Context, req resource.Re
 			note
 			projectId
 			startDate
-			activityType {
-			  id
-			}
+			activityTypeId
 			serverRoleId
 		}
 	}`
 	tflog.Debug(ctx, fmt.Sprintf("Query server checkout ID: %v", state.ID.ValueInt64()))
 	request := graphql.NewRequest(queryservercheckout)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		ServerCheckout []ghostwriterServerCheckout `json:"serverCheckout"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter server checkout ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"serverCheckout": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Server Checkout",
+			"Could not read Ghostwriter server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Server checkout response: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Server checkout response: %s", responseString(respData)))
 
 	// Overwrite items with refreshed state
-	server_checkouts := respData["serverCheckout"].([]interface{})
+	server_checkouts := respData.ServerCheckout
 	if len(server_checkouts) >= 1 {
-		latest_checkout := server_checkouts[0].(map[string]interface{})
-		state.ActivityTypeId = types.Int64Value(int64(latest_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		state.ServerId = types.Int64Value(int64(latest_checkout["serverId"].(float64)))
-		state.ProjectId = types.Int64Value(int64(latest_checkout["projectId"].(float64)))
-		state.Note = types.StringValue(latest_checkout["note"].(string))
-		state.StartDate = types.StringValue(latest_checkout["startDate"].(string))
-		state.EndDate = types.StringValue(latest_checkout["endDate"].(string))
-		state.ServerRoleId = types.Int64Value(int64(latest_checkout["serverRoleId"].(float64)))
+		latest_checkout := server_checkouts[0]
+		state.ActivityTypeId = int64ValueOrZero(latest_checkout.ActivityTypeID)
+		state.ServerId = int64ValueOrZero(latest_checkout.ServerID)
+		state.ProjectId = int64ValueOrZero(latest_checkout.ProjectID)
+		state.Note = stringValueOrEmpty(latest_checkout.Note)
+		state.StartDate = stringValueOrEmpty(latest_checkout.StartDate)
+		state.EndDate = stringValueOrEmpty(latest_checkout.EndDate)
+		state.ServerRoleId = int64ValueOrZero(latest_checkout.ServerRoleID)
 
 		// Set refreshed state
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter server checkout ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -331,6 +503,14 @@ func (r *staticserverCheckoutResource) Update(ctx context.Context, req resource.
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updateservercheckout = `mutation UpdateServerCheckout ($id: bigint, $activity_type_id: bigint, $server_id: bigint, $project_id: bigint, $note: String, $start_date: date!, $end_date: date!, $server_role_id: bigint) {
 		update_serverCheckout(where: {id: {_eq: $id}}, _set: {activityTypeId: $activity_type_id, serverId: $server_id, endDate: $end_date, note: $note, projectId: $project_id, startDate: $start_date, serverRoleId: $server_role_id}) {
@@ -341,9 +521,7 @@ func (r *staticserverCheckoutResource) Update(ctx context.Context, req resource.
 				note
 				projectId
 				startDate
-				activityType {
-				  id
-				}
+				activityTypeId
 				serverRoleId
 			}
 		}
@@ -358,7 +536,9 @@ func (r *staticserverCheckoutResource) Update(ctx context.Context, req resource.
 	request.Var("start_date", plan.StartDate.ValueString())
 	request.Var("end_date", plan.EndDate.ValueString())
 	request.Var("server_role_id", plan.ServerRoleId.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateServerCheckout mutationResponse[ghostwriterServerCheckout] `json:"update_serverCheckout"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Server Checkout",
@@ -367,19 +547,19 @@ func (r *staticserverCheckoutResource) Update(ctx context.Context, req resource.
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
 
-	updated_server_checkouts := respData["update_serverCheckout"].(map[string]interface{})["returning"].([]interface{})
+	updated_server_checkouts := respData.UpdateServerCheckout.Returning
 	if len(updated_server_checkouts) == 1 {
-		updated_server_checkout := updated_server_checkouts[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(updated_server_checkout["id"].(float64)))
-		plan.ActivityTypeId = types.Int64Value(int64(updated_server_checkout["activityType"].(map[string]interface{})["id"].(float64)))
-		plan.ServerId = types.Int64Value(int64(updated_server_checkout["serverId"].(float64)))
-		plan.ProjectId = types.Int64Value(int64(updated_server_checkout["projectId"].(float64)))
-		plan.Note = types.StringValue(updated_server_checkout["note"].(string))
-		plan.StartDate = types.StringValue(updated_server_checkout["startDate"].(string))
-		plan.EndDate = types.StringValue(updated_server_checkout["endDate"].(string))
-		plan.ServerRoleId = types.Int64Value(int64(updated_server_checkout["serverRoleId"].(float64)))
+		updated_server_checkout := updated_server_checkouts[0]
+		plan.ID = types.Int64Value(updated_server_checkout.ID)
+		plan.ActivityTypeId = int64ValueOrZero(updated_server_checkout.ActivityTypeID)
+		plan.ServerId = int64ValueOrZero(updated_server_checkout.ServerID)
+		plan.ProjectId = int64ValueOrZero(updated_server_checkout.ProjectID)
+		plan.Note = stringValueOrEmpty(updated_server_checkout.Note)
+		plan.StartDate = stringValueOrEmpty(updated_server_checkout.StartDate)
+		plan.EndDate = stringValueOrEmpty(updated_server_checkout.EndDate)
+		plan.ServerRoleId = int64ValueOrZero(updated_server_checkout.ServerRoleID)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -395,6 +575,7 @@ func (r *staticserverCheckoutResource) Update(ctx context.Context, req resource.
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_static_server_checkout", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -407,10 +588,21 @@ func (r *staticserverCheckoutResource) Delete(ctx context.Context, req resource.
 		return
 	}
 
-	if state.ForceDelete.ValueBool() {
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
+	on_destroy := onDestroyMode(state.OnDestroy, state.ForceDelete)
+	switch on_destroy {
+	case onDestroyDelete:
 		// Generate API request body from plan
 		const deleteservercheckout = `mutation DeleteServerCheckout ($id: bigint) {
 			delete_serverCheckout(where: {id: {_eq: $id}}) {
+				affected_rows
 				returning {
 					id
 				}
@@ -419,30 +611,136 @@ func (r *staticserverCheckoutResource) Delete(ctx context.Context, req resource.
 		tflog.Debug(ctx, fmt.Sprintf("Deleting server checkout: %v", state))
 		request := graphql.NewRequest(deleteservercheckout)
 		request.Var("id", state.ID.ValueInt64())
-		var respData map[string]interface{}
-		if err := r.client.Run(ctx, request, &respData); err != nil {
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Server Checkout", "server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
 			return
 		}
-	} else {
-		tflog.Info(ctx, "Cowardly refusing to delete server checkout. Releasing server to the ghostwriter pool and the server checkout record will remain. Set force_delete to true to delete server checkout record.")
+	case onDestroyExpireNow:
+		const expireservercheckout = `mutation ExpireServerCheckout ($id: bigint, $end_date: date) {
+			update_serverCheckout(where: {id: {_eq: $id}}, _set: {endDate: $end_date}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		tflog.Debug(ctx, fmt.Sprintf("Expiring server checkout: %v", state))
+		request := graphql.NewRequest(expireservercheckout)
+		request.Var("id", state.ID.ValueInt64())
+		request.Var("end_date", checkoutExpiryDate(state.StartDate))
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "expire", "Error Expiring Ghostwriter Server Checkout", "server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+	case onDestroyBurn:
+		// Servers have neither a burned status nor a burned explanation, so the server is set to
+		// Unavailable instead of release_status so it is not handed out again, and the explanation
+		// is appended to the note of the checkout that is kept as history. The note is updated last
+		// and only once, so a destroy retried after a failure does not repeat the explanation.
+		const burnserver = `mutation BurnServer ($id: bigint, $status_id: bigint) {
+			update_staticServer(where: {id: {_eq: $id}}, _set: {serverStatusId: $status_id}) {
+				affected_rows
+				returning {
+					id
+				}
+			}
+		}`
+		tflog.Debug(ctx, fmt.Sprintf("Burning server: %v", state))
+		status_id, err := r.client.lookupID(ctx, "serverStatus", "serverStatus", unavailableServerStatus)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				"Error Burning Ghostwriter Static Server",
+				"Could not resolve the server status "+unavailableServerStatus+": "+err.Error(),
+			)
+			return
+		}
+		server_request := graphql.NewRequest(burnserver)
+		server_request.Var("id", state.ServerId.ValueInt64())
+		server_request.Var("status_id", status_id)
+		resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, server_request, "burn", "Error Burning Ghostwriter Static Server", "server ID "+strconv.FormatInt(state.ServerId.ValueInt64(), 10))...)
+		if resp.Diagnostics.HasError() {
+			return
+		}
+
+		const queryservercheckout = `query QueryServerCheckout ($id: bigint) {
+			serverCheckout(where: {id: {_eq: $id}}) {
+				id
+				note
+			}
+		}`
+		query_request := graphql.NewRequest(queryservercheckout)
+		query_request.Var("id", state.ID.ValueInt64())
+		var queryResp struct {
+			ServerCheckout []ghostwriterServerCheckout `json:"serverCheckout"`
+		}
+		if err := r.client.Run(ctx, query_request, &queryResp); err != nil {
+			resp.Diagnostics.AddError(
+				"Error Burning Ghostwriter Static Server",
+				"Could not read server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+			)
+			return
+		}
+		note := state.Note.ValueString()
+		if len(queryResp.ServerCheckout) == 1 {
+			note = stringValueOrEmpty(queryResp.ServerCheckout[0].Note).ValueString()
+		}
+		burn_line := "Burned: " + state.BurnExplanation.ValueString()
+		if !strings.HasSuffix(note, burn_line) {
+			if note != "" {
+				note += "\n"
+			}
+			note += burn_line
+
+			const burnservercheckout = `mutation BurnServerCheckout ($id: bigint, $note: String) {
+				update_serverCheckout(where: {id: {_eq: $id}}, _set: {note: $note}) {
+					affected_rows
+					returning {
+						id
+					}
+				}
+			}`
+			request := graphql.NewRequest(burnservercheckout)
+			request.Var("id", state.ID.ValueInt64())
+			request.Var("note", note)
+			resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "burn", "Error Burning Ghostwriter Static Server", "server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+			if resp.Diagnostics.HasError() {
+				return
+			}
+		}
+		resp.Diagnostics.Append(r.client.audit(ctx, onDestroyAuditActions[on_destroy], "ghostwriter_static_server_checkout", req.State.Raw, tftypes.Value{})...)
+		return
+	default:
+		tflog.Info(ctx, "Cowardly refusing to delete server checkout. Releasing server to the ghostwriter pool and the server checkout record will remain. Set on_destroy to delete to delete server checkout record.")
 	}
 	// Generate API request body from plan
-	const releaseserver = `mutation Updateserver ($id: bigint) {
-		update_staticServer(where: {id: {_eq: $id}}, _set: {serverStatusId: 1}) {
+	const releaseserver = `mutation Updateserver ($id: bigint, $status_id: bigint) {
+		update_staticServer(where: {id: {_eq: $id}}, _set: {serverStatusId: $status_id}) {
+			affected_rows
 			returning {
 				id
 			}
 		}
 	}`
 	tflog.Debug(ctx, fmt.Sprintf("Releasing server to the pool: %v", state))
+	release_status := state.ReleaseStatus.ValueString()
+	if release_status == "" {
+		release_status = defaultReleaseStatus
+	}
+	status_id, err := r.client.lookupID(ctx, "serverStatus", "serverStatus", release_status)
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			path.Root("release_status"),
+			"Error Releasing Ghostwriter Static Server",
+			"Could not resolve the server status "+release_status+": "+err.Error(),
+		)
+		return
+	}
 	request := graphql.NewRequest(releaseserver)
 	request.Var("id", state.ServerId.ValueInt64())
-	var respData map[string]interface{}
-	if err := r.client.Run(ctx, request, &respData); err != nil {
-		resp.Diagnostics.AddError(
-			"Error Deleting Ghostwriter Server Checkout",
-			"Could not delete server checkout ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
-		)
+	request.Var("status_id", status_id)
+	resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "release", "Error Releasing Ghostwriter Static Server", "server ID "+strconv.FormatInt(state.ServerId.ValueInt64(), 10))...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, onDestroyAuditActions[on_destroy], "ghostwriter_static_server_checkout", req.State.Raw, tftypes.Value{})...)
 }
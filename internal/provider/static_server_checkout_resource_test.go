@@ -1,6 +1,7 @@
 package provider
 
 import (
+	"regexp"
 	"testing"
 
 	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
@@ -33,6 +34,7 @@ resource "ghostwriter_static_server_checkout" "test" {
 					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "note", "Test Note"),
 					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "server_role_id", "1"),
 					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "force_delete", "true"),
+					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "release_status", "Available"),
 					resource.TestCheckResourceAttrSet("ghostwriter_static_server_checkout.test", "id"),
 					resource.TestCheckResourceAttrSet("ghostwriter_static_server_checkout.test", "last_updated"),
 				),
@@ -76,3 +78,82 @@ resource "ghostwriter_static_server_checkout" "test" {
 		},
 	})
 }
+
+func TestStaticServerCheckoutResourceByName(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+data "ghostwriter_activity_type" "test" {
+  name = "Command and Control"
+}
+
+data "ghostwriter_server_role" "test" {
+  name = "Team Server / C2 Server"
+}
+
+resource "ghostwriter_static_server_checkout" "test" {
+  project_id    = 1
+  server_id     = 1
+  start_date    = "2024-01-01"
+  end_date      = "2025-01-01"
+  activity_type = "Command and Control"
+  server_role   = "Team Server / C2 Server"
+  force_delete  = true
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "activity_type", "Command and Control"),
+					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "server_role", "Team Server / C2 Server"),
+					resource.TestCheckResourceAttrPair("ghostwriter_static_server_checkout.test", "activity_type_id", "data.ghostwriter_activity_type.test", "id"),
+					resource.TestCheckResourceAttrPair("ghostwriter_static_server_checkout.test", "server_role_id", "data.ghostwriter_server_role.test", "id"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
+
+func TestStaticServerCheckoutResourceOnDestroy(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Validation testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_static_server_checkout" "test" {
+  project_id       = 1
+  server_id        = 1
+  start_date       = "2024-01-01"
+  end_date         = "2025-01-01"
+  activity_type_id = 1
+  server_role_id   = 1
+  on_destroy       = "burn"
+}
+`,
+				ExpectError: regexp.MustCompile("Missing Burn Explanation"),
+			},
+			// Create and Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_static_server_checkout" "test" {
+  project_id       = 1
+  server_id        = 1
+  start_date       = "2024-01-01"
+  end_date         = "2025-01-01"
+  activity_type_id = 1
+  server_role_id   = 1
+  on_destroy       = "expire_now"
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "on_destroy", "expire_now"),
+					resource.TestCheckResourceAttr("ghostwriter_static_server_checkout.test", "force_delete", "false"),
+				),
+			},
+			// Delete testing automatically occurs in TestCase
+		},
+	})
+}
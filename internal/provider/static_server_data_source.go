@@ -0,0 +1,263 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	"github.com/machinebox/graphql"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &staticServerDataSource{}
+	_ datasource.DataSourceWithConfigure = &staticServerDataSource{}
+)
+
+// NewstaticServerDataSource is a helper function to simplify the provider implementation.
+func NewstaticServerDataSource() datasource.DataSource {
+	return &staticServerDataSource{}
+}
+
+// staticServerDataSource is the data source implementation.
+type staticServerDataSource struct {
+	client *ghostwriterClient
+}
+
+// staticServerDataSourceModel maps the static server schema data.
+type staticServerDataSourceModel struct {
+	ID                  types.Int64    `tfsdk:"id"`
+	Name                types.String   `tfsdk:"name"`
+	IpAddress           types.String   `tfsdk:"ip_address"`
+	ServerProviderID    types.Int64    `tfsdk:"server_provider_id"`
+	ServerStatusID      types.Int64    `tfsdk:"server_status_id"`
+	Note                types.String   `tfsdk:"note"`
+	AuxAddresses        []types.String `tfsdk:"aux_addresses"`
+	CurrentCheckoutID   types.Int64    `tfsdk:"current_checkout_id"`
+	CurrentProjectID    types.Int64    `tfsdk:"current_project_id"`
+	CurrentServerRoleID types.Int64    `tfsdk:"current_server_role_id"`
+}
+
+// Metadata returns the data source type name.
+func (d *staticServerDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_static_server"
+}
+
+// Configure adds the provider configured client to the datasource.
+func (d *staticServerDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Schema defines the schema for the data source.
+func (d *staticServerDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Search an existing static server in ghostwriter by name or IP address.",
+		Attributes: map[string]schema.Attribute{
+			"id": schema.Int64Attribute{
+				Description: "The identifier of the server.",
+				Computed:    true,
+			},
+			"name": schema.StringAttribute{
+				Description: "The name of the server typically its hostname. Exactly one of name or ip_address must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+					stringvalidator.ExactlyOneOf(path.MatchRoot("ip_address")),
+				},
+			},
+			"ip_address": schema.StringAttribute{
+				Description: "The servers IP address. Exactly one of name or ip_address must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"server_provider_id": schema.Int64Attribute{
+				Description: "The identifier of the server hosting provider.",
+				Computed:    true,
+			},
+			"server_status_id": schema.Int64Attribute{
+				Description: "The identifier of the server status.",
+				Computed:    true,
+			},
+			"note": schema.StringAttribute{
+				Description: "Additional notes about the server.",
+				Computed:    true,
+			},
+			"aux_addresses": schema.ListAttribute{
+				Description: "The auxiliary IP addresses of the server.",
+				ElementType: types.StringType,
+				Computed:    true,
+			},
+			"current_checkout_id": schema.Int64Attribute{
+				Description: "The ID of the checkout the server is currently used by, or 0 if it is not checked out.",
+				Computed:    true,
+			},
+			"current_project_id": schema.Int64Attribute{
+				Description: "The ID of the project the server is currently checked out to, or 0 if it is not checked out.",
+				Computed:    true,
+			},
+			"current_server_role_id": schema.Int64Attribute{
+				Description: "The ID of the role of the server in its current checkout, or 0 if it is not checked out.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *staticServerDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var config staticServerDataSourceModel
+	diags := req.Config.Get(ctx, &config)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	where := map[string]any{}
+	target := "static server " + config.Name.ValueString()
+	if !config.IpAddress.IsNull() {
+		where["ipAddress"] = map[string]any{"_eq": config.IpAddress.ValueString()}
+		target = "static server with IP address " + config.IpAddress.ValueString()
+	} else {
+		where["name"] = map[string]any{"_eq": config.Name.ValueString()}
+	}
+
+	servers, err := readStaticServers(ctx, d.client, where)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Static Server",
+			"Could not read Ghostwriter "+target+": "+err.Error(),
+		)
+		return
+	}
+	if len(servers) != 1 {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Static Server",
+			fmt.Sprintf("Could not read Ghostwriter %s: expected exactly one match, found %d.", target, len(servers)),
+		)
+		return
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &servers[0])
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
+
+// readStaticServers returns the static servers matching a Hasura staticServer_bool_exp, along with
+// their auxiliary addresses and current checkouts.
+func readStaticServers(ctx context.Context, client *ghostwriterClient, where map[string]any) ([]staticServerDataSourceModel, error) {
+	const querystaticservers = `query QueryStaticServers ($where: staticServer_bool_exp!) {
+		staticServer(where: $where, order_by: {name: asc}) {
+			id,
+			name,
+			serverProviderId,
+			serverStatusId,
+			ipAddress,
+			note
+		}
+	}`
+	request := graphql.NewRequest(querystaticservers)
+	request.Var("where", where)
+	var respData struct {
+		StaticServer []ghostwriterStaticServer `json:"staticServer"`
+	}
+	if err := client.Run(ctx, request, &respData); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	servers := []staticServerDataSourceModel{}
+	if len(respData.StaticServer) == 0 {
+		return servers, nil
+	}
+	server_ids := []int64{}
+	for _, server := range respData.StaticServer {
+		server_ids = append(server_ids, server.ID)
+	}
+
+	const querydetails = `query QueryStaticServerDetails ($ids: [bigint!], $today: date!) {
+		auxServerAddress(where: {staticServerId: {_in: $ids}}, order_by: {id: asc}) {
+			id
+			staticServerId
+			ipAddress
+		}
+		serverCheckout(where: {serverId: {_in: $ids}, startDate: {_lte: $today}, endDate: {_gte: $today}}, order_by: {id: desc}) {
+			id
+			serverId
+			projectId
+			serverRoleId
+		}
+	}`
+	details_request := graphql.NewRequest(querydetails)
+	details_request.Var("ids", server_ids)
+	details_request.Var("today", time.Now().Format(time.DateOnly))
+	var detailsResp struct {
+		AuxServerAddress []ghostwriterAuxServerAddress `json:"auxServerAddress"`
+		ServerCheckout   []ghostwriterServerCheckout   `json:"serverCheckout"`
+	}
+	if err := client.Run(ctx, details_request, &detailsResp); err != nil {
+		return nil, err
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(detailsResp)))
+	for _, server := range respData.StaticServer {
+		model := staticServerDataSourceModel{
+			ID:                  types.Int64Value(server.ID),
+			Name:                stringValueOrEmpty(server.Name),
+			IpAddress:           stringValueOrEmpty(server.IpAddress),
+			ServerProviderID:    int64ValueOrZero(server.ServerProviderID),
+			ServerStatusID:      int64ValueOrZero(server.ServerStatusID),
+			Note:                stringValueOrEmpty(server.Note),
+			AuxAddresses:        []types.String{},
+			CurrentCheckoutID:   types.Int64Value(0),
+			CurrentProjectID:    types.Int64Value(0),
+			CurrentServerRoleID: types.Int64Value(0),
+		}
+		for _, address := range detailsResp.AuxServerAddress {
+			if address.StaticServerID != nil && *address.StaticServerID == server.ID {
+				model.AuxAddresses = append(model.AuxAddresses, stringValueOrEmpty(address.IpAddress))
+			}
+		}
+		// Checkouts are ordered newest first, so the first match is the current checkout
+		for _, checkout := range detailsResp.ServerCheckout {
+			if checkout.ServerID != nil && *checkout.ServerID == server.ID {
+				model.CurrentCheckoutID = types.Int64Value(checkout.ID)
+				model.CurrentProjectID = int64ValueOrZero(checkout.ProjectID)
+				model.CurrentServerRoleID = int64ValueOrZero(checkout.ServerRoleID)
+				break
+			}
+		}
+		servers = append(servers, model)
+	}
+	return servers, nil
+}
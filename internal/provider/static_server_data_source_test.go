@@ -0,0 +1,36 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestStaticServerDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_static_server" "test" {
+  name               = "static-server-data-source"
+  server_provider_id = 1
+  ip_address         = "192.168.0.20"
+}
+
+data "ghostwriter_static_server" "test" {
+  ip_address = ghostwriter_static_server.test.ip_address
+  depends_on = [ghostwriter_static_server.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrPair("data.ghostwriter_static_server.test", "id", "ghostwriter_static_server.test", "id"),
+					resource.TestCheckResourceAttr("data.ghostwriter_static_server.test", "name", "static-server-data-source"),
+					resource.TestCheckResourceAttr("data.ghostwriter_static_server.test", "server_provider_id", "1"),
+					resource.TestCheckResourceAttr("data.ghostwriter_static_server.test", "current_checkout_id", "0"),
+				),
+			},
+		},
+	})
+}
@@ -7,14 +7,19 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/hashicorp/terraform-plugin-framework-timeouts/resource/timeouts"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
 	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	"github.com/machinebox/graphql"
 )
@@ -23,6 +28,7 @@ import (
 var (
 	_ resource.Resource                = &staticserverResource{}
 	_ resource.ResourceWithConfigure   = &staticserverResource{}
+	_ resource.ResourceWithModifyPlan  = &staticserverResource{}
 	_ resource.ResourceWithImportState = &staticserverResource{}
 )
 
@@ -33,18 +39,20 @@ func NewstaticserverResource() resource.Resource {
 
 // staticserverResource is the resource implementation.
 type staticserverResource struct {
-	client *graphql.Client
+	client *ghostwriterClient
 }
 
 // orderResourceModel maps the resource schema data.
 type staticserverResourceModel struct {
-	ID               types.Int64  `tfsdk:"id"`
-	Name             types.String `tfsdk:"name"`
-	ServerProviderID types.Int64  `tfsdk:"server_provider_id"`
-	ServerStatusId   types.Int64  `tfsdk:"server_status_id"`
-	IpAddress        types.String `tfsdk:"ip_address"`
-	Note             types.String `tfsdk:"note"`
-	LastUpdated      types.String `tfsdk:"last_updated"`
+	ID               types.Int64    `tfsdk:"id"`
+	Name             types.String   `tfsdk:"name"`
+	ServerProviderID types.Int64    `tfsdk:"server_provider_id"`
+	ServerProvider   types.String   `tfsdk:"server_provider"`
+	ServerStatusId   types.Int64    `tfsdk:"server_status_id"`
+	IpAddress        types.String   `tfsdk:"ip_address"`
+	Note             types.String   `tfsdk:"note"`
+	LastUpdated      types.String   `tfsdk:"last_updated"`
+	Timeouts         timeouts.Value `tfsdk:"timeouts"`
 }
 
 // Metadata returns the resource type name.
@@ -60,12 +68,12 @@ func (r *staticserverResource) Configure(_ context.Context, req resource.Configu
 		return
 	}
 
-	client, ok := req.ProviderData.(*graphql.Client)
+	client, ok := req.ProviderData.(*ghostwriterClient)
 
 	if !ok {
 		resp.Diagnostics.AddError(
 			"Unexpected Data Source Configure Type",
-			fmt.Sprintf("Expected *graphql.Client, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
 		)
 
 		return
@@ -75,13 +83,16 @@ func (r *staticserverResource) Configure(_ context.Context, req resource.Configu
 }
 
 // Schema defines the schema for the resource.
-func (r *staticserverResource) Schema(_ context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
+func (r *staticserverResource) Schema(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
 	resp.Schema = schema.Schema{
 		Description: "Register a static server in Ghostwriter.",
 		Attributes: map[string]schema.Attribute{
 			"id": schema.Int64Attribute{
 				Description: "Placeholder identifier attribute",
 				Computed:    true,
+				PlanModifiers: []planmodifier.Int64{
+					int64planmodifier.UseStateForUnknown(),
+				},
 			},
 			"last_updated": schema.StringAttribute{
 				Description: "Timestamp of the last Terraform update of the server.",
@@ -94,8 +105,19 @@ func (r *staticserverResource) Schema(_ context.Context, _ resource.SchemaReques
 				Default:     stringdefault.StaticString(""),
 			},
 			"server_provider_id": schema.Int64Attribute{
-				Description: "The identifier of the server hosting provider.",
-				Required:    true,
+				Description: "The identifier of the server hosting provider. Exactly one of server_provider_id or server_provider must be set.",
+				Optional:    true,
+				Computed:    true,
+				Validators: []validator.Int64{
+					int64validator.ExactlyOneOf(path.MatchRoot("server_provider")),
+				},
+			},
+			"server_provider": schema.StringAttribute{
+				Description: "The name of the server hosting provider, resolved to server_provider_id at plan time. e.g. Amazon Web Services",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
 			},
 			"server_status_id": schema.Int64Attribute{
 				Description: "The identifier of the server status.",
@@ -124,7 +146,24 @@ func (r *staticserverResource) Schema(_ context.Context, _ resource.SchemaReques
 				},
 			},
 		},
+		Blocks: map[string]schema.Block{
+			"timeouts": timeouts.Block(ctx, timeouts.Opts{
+				Create: true,
+				Read:   true,
+				Update: true,
+				Delete: true,
+			}),
+		},
+	}
+}
+
+// ModifyPlan resolves the lookup table references configured by name.
+func (r *staticserverResource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	// Nothing to resolve when the resource is destroyed or the provider is not configured yet
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		return
 	}
+	resp.Diagnostics.Append(r.client.resolveReferences(ctx, &resp.Plan, serverProviderReference)...)
 }
 
 // ImportState imports the resource state from Terraform state.
@@ -151,6 +190,14 @@ func (r *staticserverResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
+	create_timeout, diags := plan.Timeouts.Create(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, create_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const insertserver = `mutation InsertServer($name: String, $server_provider_id: bigint, $server_status_id: bigint, $ip: inet, $note: String) {
 		insert_staticServer(objects: {name: $name, serverProviderId: $server_provider_id, serverStatusId: $server_status_id, ipAddress: $ip, note: $note}) {
@@ -171,7 +218,9 @@ func (r *staticserverResource) Create(ctx context.Context, req resource.CreateRe
 	request.Var("server_status_id", plan.ServerStatusId.ValueInt64())
 	request.Var("ip", plan.IpAddress.ValueString())
 	request.Var("note", plan.Note.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		InsertStaticServer mutationResponse[ghostwriterStaticServer] `json:"insert_staticServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error creating server",
@@ -180,16 +229,16 @@ func (r *staticserverResource) Create(ctx context.Context, req resource.CreateRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	servers := respData["insert_staticServer"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	servers := respData.InsertStaticServer.Returning
 	if len(servers) == 1 {
-		server := servers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(server["id"].(float64)))
-		plan.Name = types.StringValue(server["name"].(string))
-		plan.ServerProviderID = types.Int64Value(int64(server["serverProviderId"].(float64)))
-		plan.ServerStatusId = types.Int64Value(int64(server["serverStatusId"].(float64)))
-		plan.IpAddress = types.StringValue(server["ipAddress"].(string))
-		plan.Note = types.StringValue(server["note"].(string))
+		server := servers[0]
+		plan.ID = types.Int64Value(server.ID)
+		plan.Name = stringValueOrEmpty(server.Name)
+		plan.ServerProviderID = int64ValueOrZero(server.ServerProviderID)
+		plan.ServerStatusId = int64ValueOrZero(server.ServerStatusID)
+		plan.IpAddress = stringValueOrEmpty(server.IpAddress)
+		plan.Note = stringValueOrEmpty(server.Note)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -205,6 +254,7 @@ func (r *staticserverResource) Create(ctx context.Context, req resource.CreateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "create", "ghostwriter_static_server", tftypes.Value{}, resp.State.Raw)...)
 }
 
 // Read refreshes the Terraform state with the latest data.
@@ -217,6 +267,14 @@ func (r *staticserverResource) Read(ctx context.Context, req resource.ReadReques
 		return
 	}
 
+	read_timeout, diags := state.Timeouts.Read(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, read_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const queryserver = `query StaticServer ($id: bigint){
 		staticServer(where: {id: {_eq: $id}}) {
@@ -231,30 +289,34 @@ func (r *staticserverResource) Read(ctx context.Context, req resource.ReadReques
 	tflog.Debug(ctx, fmt.Sprintf("Reading server: %v", state.ID))
 	request := graphql.NewRequest(queryserver)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
+	var respData struct {
+		StaticServer []ghostwriterStaticServer `json:"staticServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
-		tflog.Debug(ctx, fmt.Sprintf("Could not read Ghostwriter server ID: %v", state.ID))
-		respData = map[string]interface{}{
-			"staticServer": []interface{}{},
-		}
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Static Server",
+			"Could not read Ghostwriter server ID "+strconv.FormatInt(state.ID.ValueInt64(), 10)+": "+err.Error(),
+		)
+		return
 	}
 
 	// Overwrite items with refreshed state
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	servers := respData["staticServer"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	servers := respData.StaticServer
 	if len(servers) == 1 {
-		server := servers[0].(map[string]interface{})
-		state.ID = types.Int64Value(int64(server["id"].(float64)))
-		state.Name = types.StringValue(server["name"].(string))
-		state.ServerProviderID = types.Int64Value(int64(server["serverProviderId"].(float64)))
-		state.ServerStatusId = types.Int64Value(int64(server["serverStatusId"].(float64)))
-		state.IpAddress = types.StringValue(server["ipAddress"].(string))
-		state.Note = types.StringValue(server["note"].(string))
+		server := servers[0]
+		state.ID = types.Int64Value(server.ID)
+		state.Name = stringValueOrEmpty(server.Name)
+		state.ServerProviderID = int64ValueOrZero(server.ServerProviderID)
+		state.ServerStatusId = int64ValueOrZero(server.ServerStatusID)
+		state.IpAddress = stringValueOrEmpty(server.IpAddress)
+		state.Note = stringValueOrEmpty(server.Note)
 		state.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
 		diags = resp.State.Set(ctx, &state)
 	} else {
+		tflog.Warn(ctx, fmt.Sprintf("Ghostwriter server ID %v not found, removing from state", state.ID))
 		resp.State.RemoveResource(ctx)
 		return
 	}
@@ -277,6 +339,14 @@ func (r *staticserverResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
+	update_timeout, diags := plan.Timeouts.Update(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, update_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const updateserver = `mutation UpdateServer($id: bigint, $name: String, $server_provider_id: bigint, $server_status_id: bigint, $ip: inet, $note: String) {
 		update_staticServer(where: {id: {_eq: $id}}, _set: {name: $name, serverProviderId: $server_provider_id, serverStatusId: $server_status_id, ipAddress: $ip, note: $note}) {
@@ -298,7 +368,9 @@ func (r *staticserverResource) Update(ctx context.Context, req resource.UpdateRe
 	request.Var("server_status_id", plan.ServerStatusId.ValueInt64())
 	request.Var("ip", plan.IpAddress.ValueString())
 	request.Var("note", plan.Note.ValueString())
-	var respData map[string]interface{}
+	var respData struct {
+		UpdateStaticServer mutationResponse[ghostwriterStaticServer] `json:"update_staticServer"`
+	}
 	if err := r.client.Run(ctx, request, &respData); err != nil {
 		resp.Diagnostics.AddError(
 			"Error Updating Ghostwriter Server",
@@ -307,16 +379,16 @@ func (r *staticserverResource) Update(ctx context.Context, req resource.UpdateRe
 		return
 	}
 
-	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %v", respData))
-	updated_servers := respData["update_staticServer"].(map[string]interface{})["returning"].([]interface{})
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(respData)))
+	updated_servers := respData.UpdateStaticServer.Returning
 	if len(updated_servers) == 1 {
-		server := updated_servers[0].(map[string]interface{})
-		plan.ID = types.Int64Value(int64(server["id"].(float64)))
-		plan.Name = types.StringValue(server["name"].(string))
-		plan.ServerProviderID = types.Int64Value(int64(server["serverProviderId"].(float64)))
-		plan.ServerStatusId = types.Int64Value(int64(server["serverStatusId"].(float64)))
-		plan.IpAddress = types.StringValue(server["ipAddress"].(string))
-		plan.Note = types.StringValue(server["note"].(string))
+		server := updated_servers[0]
+		plan.ID = types.Int64Value(server.ID)
+		plan.Name = stringValueOrEmpty(server.Name)
+		plan.ServerProviderID = int64ValueOrZero(server.ServerProviderID)
+		plan.ServerStatusId = int64ValueOrZero(server.ServerStatusID)
+		plan.IpAddress = stringValueOrEmpty(server.IpAddress)
+		plan.Note = stringValueOrEmpty(server.Note)
 		plan.LastUpdated = types.StringValue(time.Now().Format(time.RFC850))
 
 		// Set state to fully populated data
@@ -332,6 +404,7 @@ func (r *staticserverResource) Update(ctx context.Context, req resource.UpdateRe
 	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "update", "ghostwriter_static_server", req.State.Raw, resp.State.Raw)...)
 }
 
 // Delete deletes the resource and removes the Terraform state on success.
@@ -344,9 +417,18 @@ func (r *staticserverResource) Delete(ctx context.Context, req resource.DeleteRe
 		return
 	}
 
+	delete_timeout, diags := state.Timeouts.Delete(ctx, defaultTimeout)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	ctx, cancel := context.WithTimeout(ctx, delete_timeout)
+	defer cancel()
+
 	// Generate API request body from plan
 	const deleteserver = `mutation DeleteServer ($id: bigint){
 		delete_staticServer(where: {id: {_eq: $id}}) {
+			affected_rows
 			returning {
 				id
 			}
@@ -354,8 +436,9 @@ func (r *staticserverResource) Delete(ctx context.Context, req resource.DeleteRe
 	}`
 	request := graphql.NewRequest(deleteserver)
 	request.Var("id", state.ID.ValueInt64())
-	var respData map[string]interface{}
-	if err := r.client.Run(ctx, request, &respData); err != nil {
+	resp.Diagnostics.Append(runDeleteMutation(ctx, r.client, request, "delete", "Error Deleting Ghostwriter Static Server", "server ID "+strconv.FormatInt(state.ID.ValueInt64(), 10))...)
+	if resp.Diagnostics.HasError() {
 		return
 	}
+	resp.Diagnostics.Append(r.client.audit(ctx, "delete", "ghostwriter_static_server", req.State.Raw, tftypes.Value{})...)
 }
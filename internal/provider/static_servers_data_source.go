@@ -0,0 +1,218 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &staticServersDataSource{}
+	_ datasource.DataSourceWithConfigure = &staticServersDataSource{}
+)
+
+// NewstaticServersDataSource is a helper function to simplify the provider implementation.
+func NewstaticServersDataSource() datasource.DataSource {
+	return &staticServersDataSource{}
+}
+
+// staticServersDataSource is the data source implementation.
+type staticServersDataSource struct {
+	client *ghostwriterClient
+}
+
+// staticServersDataSourceModel maps the static servers schema data.
+type staticServersDataSourceModel struct {
+	ServerProvider types.String                  `tfsdk:"server_provider"`
+	ServerStatus   types.String                  `tfsdk:"server_status"`
+	ServerRole     types.String                  `tfsdk:"server_role"`
+	Servers        []staticServerDataSourceModel `tfsdk:"servers"`
+}
+
+// Metadata returns the data source type name.
+func (d *staticServersDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_static_servers"
+}
+
+// Configure adds the provider configured client to the datasource.
+func (d *staticServersDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Schema defines the schema for the data source.
+func (d *staticServersDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "List the static servers registered in ghostwriter, optionally filtered.",
+		Attributes: map[string]schema.Attribute{
+			"server_provider": schema.StringAttribute{
+				Description: "Only return servers hosted by this Ghostwriter server provider. e.g. Amazon Web Services",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"server_status": schema.StringAttribute{
+				Description: "Only return servers with this Ghostwriter server status. e.g. Available, Unavailable",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"server_role": schema.StringAttribute{
+				Description: "Only return servers currently checked out with this Ghostwriter server role. e.g. Team Server / C2 Server",
+				Optional:    true,
+				Validators: []validator.String{
+					stringvalidator.LengthAtLeast(1),
+				},
+			},
+			"servers": schema.ListNestedAttribute{
+				Description: "The matching servers, ordered by name.",
+				Computed:    true,
+				NestedObject: schema.NestedAttributeObject{
+					Attributes: map[string]schema.Attribute{
+						"id": schema.Int64Attribute{
+							Description: "The identifier of the server.",
+							Computed:    true,
+						},
+						"name": schema.StringAttribute{
+							Description: "The name of the server typically its hostname.",
+							Computed:    true,
+						},
+						"ip_address": schema.StringAttribute{
+							Description: "The servers IP address.",
+							Computed:    true,
+						},
+						"server_provider_id": schema.Int64Attribute{
+							Description: "The identifier of the server hosting provider.",
+							Computed:    true,
+						},
+						"server_status_id": schema.Int64Attribute{
+							Description: "The identifier of the server status.",
+							Computed:    true,
+						},
+						"note": schema.StringAttribute{
+							Description: "Additional notes about the server.",
+							Computed:    true,
+						},
+						"aux_addresses": schema.ListAttribute{
+							Description: "The auxiliary IP addresses of the server.",
+							ElementType: types.StringType,
+							Computed:    true,
+						},
+						"current_checkout_id": schema.Int64Attribute{
+							Description: "The ID of the checkout the server is currently used by, or 0 if it is not checked out.",
+							Computed:    true,
+						},
+						"current_project_id": schema.Int64Attribute{
+							Description: "The ID of the project the server is currently checked out to, or 0 if it is not checked out.",
+							Computed:    true,
+						},
+						"current_server_role_id": schema.Int64Attribute{
+							Description: "The ID of the role of the server in its current checkout, or 0 if it is not checked out.",
+							Computed:    true,
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *staticServersDataSource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state staticServersDataSourceModel
+	diags := req.Config.Get(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+
+	// Build the Hasura filter from the configured attributes
+	where := map[string]any{}
+	if !state.ServerProvider.IsNull() {
+		provider_id, err := d.client.lookupID(ctx, "serverProvider", "serverProvider", state.ServerProvider.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_provider"),
+				"Error Reading Ghostwriter Static Servers",
+				"Could not resolve server_provider "+state.ServerProvider.String()+": "+err.Error(),
+			)
+			return
+		}
+		where["serverProviderId"] = map[string]any{"_eq": provider_id}
+	}
+	if !state.ServerStatus.IsNull() {
+		status_id, err := d.client.lookupID(ctx, "serverStatus", "serverStatus", state.ServerStatus.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_status"),
+				"Error Reading Ghostwriter Static Servers",
+				"Could not resolve server_status "+state.ServerStatus.String()+": "+err.Error(),
+			)
+			return
+		}
+		where["serverStatusId"] = map[string]any{"_eq": status_id}
+	}
+	var role_id int64
+	if !state.ServerRole.IsNull() {
+		var err error
+		role_id, err = d.client.lookupID(ctx, "serverRole", "serverRole", state.ServerRole.ValueString())
+		if err != nil {
+			resp.Diagnostics.AddAttributeError(
+				path.Root("server_role"),
+				"Error Reading Ghostwriter Static Servers",
+				"Could not resolve server_role "+state.ServerRole.String()+": "+err.Error(),
+			)
+			return
+		}
+	}
+
+	servers, err := readStaticServers(ctx, d.client, where)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter Static Servers",
+			"Could not read Ghostwriter static servers: "+err.Error(),
+		)
+		return
+	}
+
+	// Roles belong to checkouts rather than servers, so they are filtered on the current checkout
+	state.Servers = []staticServerDataSourceModel{}
+	for _, server := range servers {
+		if role_id != 0 && server.CurrentServerRoleID.ValueInt64() != role_id {
+			continue
+		}
+		state.Servers = append(state.Servers, server)
+	}
+
+	// Set state
+	diags = resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}
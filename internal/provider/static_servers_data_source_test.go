@@ -0,0 +1,37 @@
+package provider
+
+import (
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-testing/helper/resource"
+)
+
+func TestStaticServersDataSource(t *testing.T) {
+	resource.Test(t, resource.TestCase{
+		ProtoV6ProviderFactories: testAccProtoV6ProviderFactories,
+		Steps: []resource.TestStep{
+			// Read testing
+			{
+				Config: providerConfig + `
+resource "ghostwriter_static_server" "test" {
+  name               = "static-servers-data-source"
+  server_provider_id = 1
+  ip_address         = "192.168.0.21"
+}
+
+data "ghostwriter_static_servers" "test" {
+  server_status = "Available"
+  depends_on    = [ghostwriter_static_server.test]
+}
+`,
+				Check: resource.ComposeAggregateTestCheckFunc(
+					resource.TestCheckResourceAttrSet("data.ghostwriter_static_servers.test", "servers.#"),
+					resource.TestCheckTypeSetElemNestedAttrs("data.ghostwriter_static_servers.test", "servers.*", map[string]string{
+						"name":       "static-servers-data-source",
+						"ip_address": "192.168.0.21",
+					}),
+				),
+			},
+		},
+	})
+}
@@ -0,0 +1,63 @@
+package provider
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"os"
+)
+
+// tlsSettings holds the provider settings used to build the TLS configuration of the API client.
+type tlsSettings struct {
+	insecure   bool
+	caCertFile string
+	caCertPEM  string
+	clientCert string
+	clientKey  string
+}
+
+// buildTLSConfig returns the TLS configuration for connecting to the Ghostwriter API. Custom
+// CA certificates are trusted in addition to the system pool, and a client certificate is
+// presented when both client_cert and client_key are set.
+func buildTLSConfig(settings tlsSettings) (*tls.Config, error) {
+	config := &tls.Config{
+		MinVersion:         tls.VersionTLS12,
+		InsecureSkipVerify: settings.insecure,
+	}
+
+	if settings.caCertFile != "" || settings.caCertPEM != "" {
+		pool, err := x509.SystemCertPool()
+		if err != nil {
+			pool = x509.NewCertPool()
+		}
+		if settings.caCertFile != "" {
+			pem, err := os.ReadFile(settings.caCertFile)
+			if err != nil {
+				return nil, fmt.Errorf("reading ca_cert_file: %w", err)
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("ca_cert_file %s does not contain any PEM encoded certificates", settings.caCertFile)
+			}
+		}
+		if settings.caCertPEM != "" {
+			if !pool.AppendCertsFromPEM([]byte(settings.caCertPEM)) {
+				return nil, errors.New("ca_cert_pem does not contain any PEM encoded certificates")
+			}
+		}
+		config.RootCAs = pool
+	}
+
+	if settings.clientCert != "" || settings.clientKey != "" {
+		if settings.clientCert == "" || settings.clientKey == "" {
+			return nil, errors.New("client_cert and client_key must be set together")
+		}
+		certificate, err := tls.X509KeyPair([]byte(settings.clientCert), []byte(settings.clientKey))
+		if err != nil {
+			return nil, fmt.Errorf("loading client certificate: %w", err)
+		}
+		config.Certificates = []tls.Certificate{certificate}
+	}
+
+	return config, nil
+}
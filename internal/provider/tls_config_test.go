@@ -0,0 +1,147 @@
+package provider
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// testCertificate returns a self-signed PEM encoded certificate and its private key.
+func testCertificate(t *testing.T) (string, string) {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("generating key: %s", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "ghostwriter-test"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		IsCA:         true,
+		KeyUsage:     x509.KeyUsageCertSign | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth, x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("creating certificate: %s", err)
+	}
+	key_der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatalf("encoding key: %s", err)
+	}
+	cert_pem := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	key_pem := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: key_der})
+	return string(cert_pem), string(key_pem)
+}
+
+func TestBuildTLSConfig(t *testing.T) {
+	cert_pem, key_pem := testCertificate(t)
+	ca_file := filepath.Join(t.TempDir(), "ca.pem")
+	if err := os.WriteFile(ca_file, []byte(cert_pem), 0o600); err != nil {
+		t.Fatalf("writing CA file: %s", err)
+	}
+	invalid_file := filepath.Join(t.TempDir(), "invalid.pem")
+	if err := os.WriteFile(invalid_file, []byte("not a certificate"), 0o600); err != nil {
+		t.Fatalf("writing invalid CA file: %s", err)
+	}
+
+	tests := []struct {
+		name             string
+		settings         tlsSettings
+		wantErr          string
+		wantRootCAs      bool
+		wantCertificates int
+		wantInsecure     bool
+	}{
+		{
+			name:     "defaults",
+			settings: tlsSettings{},
+		},
+		{
+			name:        "CA file",
+			settings:    tlsSettings{caCertFile: ca_file},
+			wantRootCAs: true,
+		},
+		{
+			name:        "inline CA PEM",
+			settings:    tlsSettings{caCertPEM: cert_pem},
+			wantRootCAs: true,
+		},
+		{
+			name:     "missing CA file",
+			settings: tlsSettings{caCertFile: filepath.Join(t.TempDir(), "missing.pem")},
+			wantErr:  "reading ca_cert_file",
+		},
+		{
+			name:     "invalid CA file",
+			settings: tlsSettings{caCertFile: invalid_file},
+			wantErr:  "does not contain any PEM encoded certificates",
+		},
+		{
+			name:     "invalid inline CA PEM",
+			settings: tlsSettings{caCertPEM: "not a certificate"},
+			wantErr:  "ca_cert_pem does not contain any PEM encoded certificates",
+		},
+		{
+			name:             "client certificate",
+			settings:         tlsSettings{clientCert: cert_pem, clientKey: key_pem},
+			wantCertificates: 1,
+		},
+		{
+			name:     "client certificate without key",
+			settings: tlsSettings{clientCert: cert_pem},
+			wantErr:  "client_cert and client_key must be set together",
+		},
+		{
+			name:     "client key without certificate",
+			settings: tlsSettings{clientKey: key_pem},
+			wantErr:  "client_cert and client_key must be set together",
+		},
+		{
+			name:     "invalid client certificate",
+			settings: tlsSettings{clientCert: "not a certificate", clientKey: key_pem},
+			wantErr:  "loading client certificate",
+		},
+		{
+			name:         "insecure with CA",
+			settings:     tlsSettings{insecure: true, caCertPEM: cert_pem},
+			wantRootCAs:  true,
+			wantInsecure: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			config, err := buildTLSConfig(test.settings)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("expected error containing %q, got %v", test.wantErr, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if (config.RootCAs != nil) != test.wantRootCAs {
+				t.Errorf("expected custom root CAs %t, got %t", test.wantRootCAs, config.RootCAs != nil)
+			}
+			if len(config.Certificates) != test.wantCertificates {
+				t.Errorf("expected %d client certificates, got %d", test.wantCertificates, len(config.Certificates))
+			}
+			if config.InsecureSkipVerify != test.wantInsecure {
+				t.Errorf("expected InsecureSkipVerify %t, got %t", test.wantInsecure, config.InsecureSkipVerify)
+			}
+		})
+	}
+}
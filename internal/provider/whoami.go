@@ -0,0 +1,115 @@
+package provider
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/machinebox/graphql"
+)
+
+// ghostwriterRoles are the roles Ghostwriter assigns to users that may manage project
+// infrastructure. Any other role, such as the anonymous public role, is rejected.
+var ghostwriterRoles = []string{"user", "manager", "admin"}
+
+// fetchWhoami returns the identity of the user the client is authenticated as.
+func fetchWhoami(ctx context.Context, client *ghostwriterClient) (ghostwriterWhoami, error) {
+	const queryWhoami = `query Whoami {
+		whoami {
+			username
+			role
+			expires
+		}
+	}`
+	request := graphql.NewRequest(queryWhoami)
+	var respData struct {
+		Whoami *ghostwriterWhoami `json:"whoami"`
+	}
+	if err := client.Run(ctx, request, &respData); err != nil {
+		return ghostwriterWhoami{}, err
+	}
+	if respData.Whoami == nil {
+		return ghostwriterWhoami{}, errors.New("whoami returned no identity")
+	}
+	return *respData.Whoami, nil
+}
+
+// validateConnection checks the client can reach Ghostwriter and is authenticated as a user
+// with a role that may manage project infrastructure. Failures are reported against the
+// attribute most likely to be at fault: the endpoint for connection problems, and
+// credentialAttribute for rejected or insufficient credentials.
+func validateConnection(ctx context.Context, client *ghostwriterClient, credentialAttribute string) (ghostwriterWhoami, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	whoami, err := fetchWhoami(ctx, client)
+	if err != nil {
+		var opErr *net.OpError
+		var dnsErr *net.DNSError
+		var certErr *tls.CertificateVerificationError
+		var loginErr *loginError
+		switch {
+		case errors.As(err, &opErr), errors.As(err, &dnsErr), errors.As(err, &certErr):
+			diags.AddAttributeError(
+				path.Root("endpoint"),
+				"Unreachable Ghostwriter API Endpoint",
+				"The provider could not connect to the Ghostwriter Graphql endpoint. "+
+					"Check the endpoint, proxy and TLS settings are correct: "+err.Error(),
+			)
+		case errors.As(err, &loginErr):
+			diags.AddAttributeError(
+				path.Root(credentialAttribute),
+				"Invalid Ghostwriter Credentials",
+				"The provider could not log in to Ghostwriter with the configured username and password: "+err.Error(),
+			)
+		case isAuthenticationError(err):
+			diags.AddAttributeError(
+				path.Root(credentialAttribute),
+				"Invalid Ghostwriter Credentials",
+				"Ghostwriter rejected the configured credentials, the API key may have expired or been revoked: "+err.Error(),
+			)
+		case strings.Contains(err.Error(), "not found in type"):
+			diags.AddAttributeError(
+				path.Root(credentialAttribute),
+				"Insufficient Ghostwriter Role",
+				"The configured credentials are not permitted to query the Ghostwriter whoami action: "+err.Error(),
+			)
+		case strings.Contains(err.Error(), "decoding response"):
+			diags.AddAttributeError(
+				path.Root("endpoint"),
+				"Invalid Ghostwriter API Endpoint",
+				"The endpoint did not return a Graphql response, ensure it points at the Ghostwriter Graphql API, e.g. https://ghostwriter.local/v1/graphql: "+err.Error(),
+			)
+		default:
+			diags.AddAttributeError(
+				path.Root("endpoint"),
+				"Unable to Validate Ghostwriter Connection",
+				"The provider could not query the Ghostwriter whoami action: "+err.Error(),
+			)
+		}
+		return whoami, diags
+	}
+
+	role := stringValueOrEmpty(whoami.Role).ValueString()
+	for _, allowed := range ghostwriterRoles {
+		if role == allowed {
+			return whoami, diags
+		}
+	}
+	diags.AddAttributeError(
+		path.Root(credentialAttribute),
+		"Insufficient Ghostwriter Role",
+		fmt.Sprintf("The configured credentials authenticate as %s with the role %q, expected one of: %s.",
+			stringValueOrEmpty(whoami.Username).ValueString(), role, strings.Join(ghostwriterRoles, ", ")),
+	)
+	return whoami, diags
+}
+
+// isAuthenticationError reports whether Hasura rejected the request's JWT.
+func isAuthenticationError(err error) bool {
+	message := strings.ToLower(err.Error())
+	return strings.Contains(message, "jwt") || strings.Contains(message, "invalid-headers") || strings.Contains(message, "access-denied")
+}
@@ -0,0 +1,108 @@
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+)
+
+// Ensure the implementation satisfies the expected interfaces.
+var (
+	_ datasource.DataSource              = &whoamiDataSource{}
+	_ datasource.DataSourceWithConfigure = &whoamiDataSource{}
+)
+
+// NewwhoamiDataSource is a helper function to simplify the provider implementation.
+func NewwhoamiDataSource() datasource.DataSource {
+	return &whoamiDataSource{}
+}
+
+// whoamiDataSource is the data source implementation.
+type whoamiDataSource struct {
+	client *ghostwriterClient
+}
+
+// whoamiDataSourceModel maps the whoami schema data.
+type whoamiDataSourceModel struct {
+	Username types.String `tfsdk:"username"`
+	Role     types.String `tfsdk:"role"`
+	Expires  types.String `tfsdk:"expires"`
+}
+
+// Metadata returns the data source type name.
+func (d *whoamiDataSource) Metadata(_ context.Context, req datasource.MetadataRequest, resp *datasource.MetadataResponse) {
+	resp.TypeName = req.ProviderTypeName + "_whoami"
+}
+
+// Configure adds the provider configured client to the datasource.
+func (d *whoamiDataSource) Configure(_ context.Context, req datasource.ConfigureRequest, resp *datasource.ConfigureResponse) {
+	// Add a nil check when handling ProviderData because Terraform
+	// sets that data after it calls the ConfigureProvider RPC.
+	if req.ProviderData == nil {
+		return
+	}
+
+	client, ok := req.ProviderData.(*ghostwriterClient)
+
+	if !ok {
+		resp.Diagnostics.AddError(
+			"Unexpected Data Source Configure Type",
+			fmt.Sprintf("Expected *ghostwriterClient, got: %T. Please report this issue to the provider developers.", req.ProviderData),
+		)
+
+		return
+	}
+
+	d.client = client
+}
+
+// Schema defines the schema for the data source.
+func (d *whoamiDataSource) Schema(_ context.Context, _ datasource.SchemaRequest, resp *datasource.SchemaResponse) {
+	resp.Schema = schema.Schema{
+		Description: "Return the ghostwriter user the provider is authenticated as.",
+		Attributes: map[string]schema.Attribute{
+			"username": schema.StringAttribute{
+				Description: "The username of the authenticated user.",
+				Computed:    true,
+			},
+			"role": schema.StringAttribute{
+				Description: "The role of the authenticated user, one of user, manager or admin.",
+				Computed:    true,
+			},
+			"expires": schema.StringAttribute{
+				Description: "When the token the provider is authenticated with expires.",
+				Computed:    true,
+			},
+		},
+	}
+}
+
+// Read refreshes the Terraform state with the latest data.
+func (d *whoamiDataSource) Read(ctx context.Context, _ datasource.ReadRequest, resp *datasource.ReadResponse) {
+	var state whoamiDataSourceModel
+
+	whoami, err := fetchWhoami(ctx, d.client)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			"Error Reading Ghostwriter identity",
+			"Could not query Ghostwriter whoami: "+err.Error(),
+		)
+		return
+	}
+
+	tflog.Debug(ctx, fmt.Sprintf("Response from Ghostwriter: %s", responseString(whoami)))
+	state.Username = stringValueOrEmpty(whoami.Username)
+	state.Role = stringValueOrEmpty(whoami.Role)
+	state.Expires = stringValueOrEmpty(whoami.Expires)
+
+	// Set state
+	diags := resp.State.Set(ctx, &state)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+}